@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrApiKeyNotFound = errors.New("api key not found")
+	ErrApiKeyExpired  = errors.New("api key expired")
+)
+
+type ApiKey struct {
+	Id string `json:"id"`
+	// Hash is the hex encoded SHA-256 of the key; the plain key is never stored.
+	Hash     string   `json:"hash"`
+	ClientId string   `json:"clientId"`
+	Scopes   []string `json:"scopes,omitempty"`
+	// ExpiresAt is the zero time for keys that never expire.
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+}
+
+func (k *ApiKey) Expired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+type ApiKeyStore interface {
+	Save(ctx context.Context, key *ApiKey) error
+	FindByHash(ctx context.Context, hash string) (*ApiKey, error)
+}
+
+func HashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateApiKey returns a random key to hand out and the hash to store.
+func GenerateApiKey() (key string, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+	key = hex.EncodeToString(b)
+	return key, HashApiKey(key), nil
+}
+
+type apiKeyProvider struct {
+	store ApiKeyStore
+}
+
+func NewApiKeyProvider(store ApiKeyStore) network.AuthenticationProvider {
+	return &apiKeyProvider{store: store}
+}
+
+func (p *apiKeyProvider) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		value := ctx.GetHeader(network.ApiKeyHeader)
+		if value == "" {
+			network.SendUnauthorizedError(ctx, "missing api key", nil)
+			return
+		}
+
+		key, err := p.store.FindByHash(ctx.Request.Context(), HashApiKey(value))
+		if err != nil {
+			if errors.Is(err, ErrApiKeyNotFound) {
+				network.SendUnauthorizedError(ctx, "invalid api key", err)
+			} else {
+				network.SendInternalServerError(ctx, "api key lookup failed", err)
+			}
+			return
+		}
+
+		if key.Expired(time.Now()) {
+			network.SendUnauthorizedError(ctx, "api key expired", ErrApiKeyExpired)
+			return
+		}
+
+		SetPrincipal(ctx, &Principal{
+			Id:     key.ClientId,
+			Type:   PrincipalApiKey,
+			Scopes: key.Scopes,
+		})
+		ctx.Next()
+	}
+}
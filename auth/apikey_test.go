@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func principalHandler(ctx *gin.Context) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		network.SendInternalServerError(ctx, "no principal", nil)
+		return
+	}
+	network.SendSuccessMsgResponse(ctx, principal.Type+":"+principal.Id+":"+strings.Join(principal.Scopes, ","))
+}
+
+func apiKeyHeader(key string) map[string]string {
+	return map[string]string{network.ApiKeyHeader: key}
+}
+
+func TestGenerateApiKey(t *testing.T) {
+	key, hash, err := GenerateApiKey()
+	assert.NoError(t, err)
+	assert.Len(t, key, 64)
+	assert.Equal(t, HashApiKey(key), hash)
+	assert.NotEqual(t, key, hash)
+}
+
+func TestApiKeyProvider(t *testing.T) {
+	store := NewMemoryApiKeyStore(
+		&ApiKey{Id: "1", Hash: HashApiKey("valid"), ClientId: "client-1", Scopes: []string{"blog:read", "blog:write"}},
+		&ApiKey{Id: "2", Hash: HashApiKey("expired"), ClientId: "client-2", ExpiresAt: time.Now().Add(-time.Minute)},
+	)
+	provider := NewApiKeyProvider(store)
+
+	t.Run("should attach the principal for a valid key", func(t *testing.T) {
+		rr := network.MockTestAuthenticationProvider(t, provider, principalHandler, apiKeyHeader("valid"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"message":"apikey:client-1:blog:read,blog:write"`)
+	})
+
+	t.Run("should reject a missing key", func(t *testing.T) {
+		rr := network.MockTestAuthenticationProvider(t, provider, principalHandler, nil)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"message":"missing api key"`)
+	})
+
+	t.Run("should reject an unknown key", func(t *testing.T) {
+		rr := network.MockTestAuthenticationProvider(t, provider, principalHandler, apiKeyHeader("unknown"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"message":"invalid api key"`)
+	})
+
+	t.Run("should reject an expired key", func(t *testing.T) {
+		rr := network.MockTestAuthenticationProvider(t, provider, principalHandler, apiKeyHeader("expired"))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"message":"api key expired"`)
+	})
+}
+
+type failingStore struct {
+	ApiKeyStore
+}
+
+func (s *failingStore) FindByHash(ctx context.Context, hash string) (*ApiKey, error) {
+	return nil, context.DeadlineExceeded
+}
+
+func TestApiKeyProvider_StoreError(t *testing.T) {
+	provider := NewApiKeyProvider(&failingStore{})
+	rr := network.MockTestAuthenticationProvider(t, provider, principalHandler, apiKeyHeader("valid"))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestJWTProvider_Principal(t *testing.T) {
+	provider, err := NewJWTProvider(JWTConfig{Secret: testSecret})
+	assert.NoError(t, err)
+
+	claims := validClaims()
+	claims.Scope = "blog:read"
+	token := signedToken(t, jwt.SigningMethodHS256, testSecret, claims, "")
+
+	rr := network.MockTestAuthenticationProvider(t, provider, principalHandler, bearer(token))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"message":"jwt:user-1:blog:read"`)
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/postgres"
+	"github.com/afteracademy/goserve/v2/redis"
+	"github.com/jackc/pgx/v5"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+type memoryApiKeyStore struct {
+	mutex sync.RWMutex
+	keys  map[string]*ApiKey
+}
+
+func NewMemoryApiKeyStore(keys ...*ApiKey) ApiKeyStore {
+	s := &memoryApiKeyStore{keys: make(map[string]*ApiKey, len(keys))}
+	for _, k := range keys {
+		s.keys[k.Hash] = k
+	}
+	return s
+}
+
+func (s *memoryApiKeyStore) Save(ctx context.Context, key *ApiKey) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.keys[key.Hash] = key
+	return nil
+}
+
+func (s *memoryApiKeyStore) FindByHash(ctx context.Context, hash string) (*ApiKey, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	key, ok := s.keys[hash]
+	if !ok {
+		return nil, ErrApiKeyNotFound
+	}
+	return key, nil
+}
+
+// postgresApiKeyStore expects a table shaped like:
+//
+//	CREATE TABLE api_keys (
+//		id         TEXT PRIMARY KEY,
+//		key_hash   TEXT NOT NULL UNIQUE,
+//		client_id  TEXT NOT NULL,
+//		scopes     TEXT[] NOT NULL DEFAULT '{}',
+//		expires_at TIMESTAMPTZ
+//	);
+type postgresApiKeyStore struct {
+	db    postgres.Database
+	table string
+}
+
+func NewPostgresApiKeyStore(db postgres.Database, table string) ApiKeyStore {
+	return &postgresApiKeyStore{
+		db:    db,
+		table: pgx.Identifier{table}.Sanitize(),
+	}
+}
+
+func (s *postgresApiKeyStore) Save(ctx context.Context, key *ApiKey) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, key_hash, client_id, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET
+			key_hash = EXCLUDED.key_hash,
+			client_id = EXCLUDED.client_id,
+			scopes = EXCLUDED.scopes,
+			expires_at = EXCLUDED.expires_at`, s.table)
+
+	var expiresAt *time.Time
+	if !key.ExpiresAt.IsZero() {
+		expiresAt = &key.ExpiresAt
+	}
+	scopes := key.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	_, err := s.db.Pool().Exec(ctx, query, key.Id, key.Hash, key.ClientId, scopes, expiresAt)
+	return err
+}
+
+func (s *postgresApiKeyStore) FindByHash(ctx context.Context, hash string) (*ApiKey, error) {
+	query := fmt.Sprintf(
+		`SELECT id, key_hash, client_id, scopes, expires_at FROM %s WHERE key_hash = $1`,
+		s.table,
+	)
+
+	var key ApiKey
+	var expiresAt *time.Time
+	err := s.db.Pool().QueryRow(ctx, query, hash).
+		Scan(&key.Id, &key.Hash, &key.ClientId, &key.Scopes, &expiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrApiKeyNotFound
+		}
+		return nil, err
+	}
+	if expiresAt != nil {
+		key.ExpiresAt = *expiresAt
+	}
+	return &key, nil
+}
+
+type redisApiKeyStore struct {
+	cache  redis.Cache[ApiKey]
+	prefix string
+}
+
+// NewRedisApiKeyStore keeps keys as JSON under prefix+hash. Keys with an
+// expiry are given a matching TTL so redis evicts them.
+func NewRedisApiKeyStore(store redis.Store, prefix string) ApiKeyStore {
+	return &redisApiKeyStore{
+		cache:  redis.NewCache[ApiKey](store),
+		prefix: prefix,
+	}
+}
+
+func (s *redisApiKeyStore) Save(ctx context.Context, key *ApiKey) error {
+	var ttl time.Duration
+	if !key.ExpiresAt.IsZero() {
+		ttl = time.Until(key.ExpiresAt)
+		if ttl <= 0 {
+			return ErrApiKeyExpired
+		}
+	}
+	return s.cache.WithContext(ctx).SetJSON(s.prefix+key.Hash, key, ttl)
+}
+
+func (s *redisApiKeyStore) FindByHash(ctx context.Context, hash string) (*ApiKey, error) {
+	key, err := s.cache.WithContext(ctx).GetJSON(s.prefix + hash)
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, ErrApiKeyNotFound
+		}
+		return nil, err
+	}
+	return key, nil
+}
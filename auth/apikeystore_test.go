@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/redis"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryApiKeyStore(t *testing.T) {
+	store := NewMemoryApiKeyStore()
+	ctx := context.Background()
+
+	_, err := store.FindByHash(ctx, HashApiKey("key"))
+	assert.ErrorIs(t, err, ErrApiKeyNotFound)
+
+	assert.NoError(t, store.Save(ctx, &ApiKey{Id: "1", Hash: HashApiKey("key"), ClientId: "client"}))
+	key, err := store.FindByHash(ctx, HashApiKey("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, "client", key.ClientId)
+}
+
+func TestRedisApiKeyStore(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rs := redis.NewStore(context.Background(), &redis.Config{
+		Host:   mr.Host(),
+		Port:   uint16(mr.Server().Addr().Port),
+		Logger: logger.Nop(),
+	})
+	defer rs.Disconnect()
+
+	store := NewRedisApiKeyStore(rs, "apikey:")
+	ctx := context.Background()
+
+	_, err := store.FindByHash(ctx, HashApiKey("key"))
+	assert.ErrorIs(t, err, ErrApiKeyNotFound)
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	err = store.Save(ctx, &ApiKey{
+		Id:        "1",
+		Hash:      HashApiKey("key"),
+		ClientId:  "client",
+		Scopes:    []string{"blog:read"},
+		ExpiresAt: expiresAt,
+	})
+	assert.NoError(t, err)
+	assert.True(t, mr.Exists("apikey:"+HashApiKey("key")))
+	assert.Greater(t, mr.TTL("apikey:"+HashApiKey("key")), 59*time.Minute)
+
+	key, err := store.FindByHash(ctx, HashApiKey("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, "client", key.ClientId)
+	assert.Equal(t, []string{"blog:read"}, key.Scopes)
+	assert.True(t, expiresAt.Equal(key.ExpiresAt))
+
+	err = store.Save(ctx, &ApiKey{Id: "2", Hash: HashApiKey("old"), ExpiresAt: time.Now().Add(-time.Second)})
+	assert.ErrorIs(t, err, ErrApiKeyExpired)
+}
@@ -0,0 +1,230 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	ErrKeyNotFound = errors.New("signing key not found")
+	ErrJWKS        = errors.New("loading jwks failed")
+)
+
+// KeySet resolves verification keys by their "kid" header.
+type KeySet interface {
+	Key(ctx context.Context, kid string) (any, error)
+}
+
+type JWKSConfig struct {
+	// Url or File is the source of the key set; Url wins when both are set.
+	Url  string
+	File string
+	// RefreshInterval reloads the set once it is older than this, 1h by default.
+	RefreshInterval time.Duration
+	// MinRefreshInterval throttles reloads triggered by an unknown kid, 1m by default.
+	MinRefreshInterval time.Duration
+	HttpClient         *http.Client
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+type jwks struct {
+	config    JWKSConfig
+	mutex     sync.RWMutex
+	keys      map[string]any
+	loadedAt  time.Time
+	refreshAt time.Time
+}
+
+// NewJWKS loads the key set once and then refreshes it lazily on lookup,
+// so rotated keys are picked up without a restart.
+func NewJWKS(ctx context.Context, config JWKSConfig) (KeySet, error) {
+	if config.Url == "" && config.File == "" {
+		return nil, fmt.Errorf("%w: url or file is required", ErrJWKS)
+	}
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = time.Hour
+	}
+	if config.MinRefreshInterval <= 0 {
+		config.MinRefreshInterval = time.Minute
+	}
+	if config.HttpClient == nil {
+		config.HttpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	set := &jwks{config: config}
+	if err := set.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+func (s *jwks) Key(ctx context.Context, kid string) (any, error) {
+	s.mutex.RLock()
+	key, ok := s.keys[kid]
+	stale := time.Since(s.loadedAt) > s.config.RefreshInterval
+	throttled := time.Since(s.refreshAt) < s.config.MinRefreshInterval
+	s.mutex.RUnlock()
+
+	if (ok && !stale) || throttled {
+		if ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("%w: kid %q", ErrKeyNotFound, kid)
+	}
+
+	if err := s.refresh(ctx); err != nil {
+		if ok {
+			// keep serving the cached key while the source is unavailable
+			return key, nil
+		}
+		return nil, err
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: kid %q", ErrKeyNotFound, kid)
+}
+
+func (s *jwks) refresh(ctx context.Context) error {
+	s.mutex.Lock()
+	s.refreshAt = time.Now()
+	s.mutex.Unlock()
+
+	data, err := s.fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrJWKS, err)
+	}
+
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	s.keys = keys
+	s.loadedAt = time.Now()
+	s.mutex.Unlock()
+	return nil
+}
+
+func (s *jwks) fetch(ctx context.Context) ([]byte, error) {
+	if s.config.Url == "" {
+		return os.ReadFile(s.config.File)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.config.Url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.config.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// ParseJWKS decodes a JSON Web Key Set into keys indexed by kid. Keys not
+// meant for signatures and unsupported key types are skipped.
+func ParseJWKS(data []byte) (map[string]any, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrJWKS, err)
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("%w: kid %q: %w", ErrJWKS, k.Kid, err)
+		}
+		if key != nil {
+			keys[k.Kid] = key
+		}
+	}
+	return keys, nil
+}
+
+func (k *jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "oct":
+		return base64.RawURLEncoding.DecodeString(k.K)
+	default:
+		return nil, nil
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
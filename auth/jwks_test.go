@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA", "kid": kid, "use": "sig",
+		"n": encodeInt(key.N), "e": encodeInt(big.NewInt(int64(key.E))),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "EC", "kid": kid, "crv": "P-256",
+		"x": encodeInt(key.X), "y": encodeInt(key.Y),
+	}
+}
+
+func jwksJSON(t *testing.T, keys ...map[string]string) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	assert.NoError(t, err)
+	return data
+}
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	data := jwksJSON(t,
+		rsaJWK("r1", &rsaKey.PublicKey),
+		ecJWK("e1", &ecKey.PublicKey),
+		map[string]string{"kty": "RSA", "kid": "enc", "use": "enc"},
+		map[string]string{"kty": "OKP", "kid": "unknown"},
+	)
+
+	keys, err := ParseJWKS(data)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+	assert.True(t, rsaKey.PublicKey.Equal(keys["r1"]))
+	assert.True(t, ecKey.PublicKey.Equal(keys["e1"]))
+
+	_, err = ParseJWKS([]byte("not json"))
+	assert.ErrorIs(t, err, ErrJWKS)
+}
+
+func TestJWKS_File(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(path, jwksJSON(t, ecJWK("e1", &ecKey.PublicKey)), 0o600))
+
+	set, err := NewJWKS(context.Background(), JWKSConfig{File: path})
+	assert.NoError(t, err)
+
+	provider, err := NewJWTProvider(JWTConfig{KeySet: set})
+	assert.NoError(t, err)
+
+	token := signedToken(t, jwt.SigningMethodES256, ecKey, validClaims(), "e1")
+	rr := network.MockTestAuthenticationProvider(t, provider, claimsHandler, bearer(token))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	token = signedToken(t, jwt.SigningMethodES256, ecKey, validClaims(), "missing")
+	rr = network.MockTestAuthenticationProvider(t, provider, claimsHandler, bearer(token))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestJWKS_UrlRotation(t *testing.T) {
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	var rotated atomic.Bool
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if rotated.Load() {
+			w.Write(jwksJSON(t, rsaJWK("new", &newKey.PublicKey)))
+			return
+		}
+		w.Write(jwksJSON(t, rsaJWK("old", &oldKey.PublicKey)))
+	}))
+	defer server.Close()
+
+	set, err := NewJWKS(context.Background(), JWKSConfig{Url: server.URL, MinRefreshInterval: time.Nanosecond})
+	assert.NoError(t, err)
+
+	key, err := set.Key(context.Background(), "old")
+	assert.NoError(t, err)
+	assert.True(t, oldKey.PublicKey.Equal(key))
+	assert.Equal(t, int32(1), fetches.Load())
+
+	rotated.Store(true)
+	key, err = set.Key(context.Background(), "new")
+	assert.NoError(t, err)
+	assert.True(t, newKey.PublicKey.Equal(key))
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestJWKS_Throttle(t *testing.T) {
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		w.Write([]byte(`{"keys":[]}`))
+	}))
+	defer server.Close()
+
+	set, err := NewJWKS(context.Background(), JWKSConfig{Url: server.URL, MinRefreshInterval: time.Hour})
+	assert.NoError(t, err)
+
+	for range 3 {
+		_, err = set.Key(context.Background(), "missing")
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	}
+	assert.Equal(t, int32(1), fetches.Load())
+}
+
+func TestNewJWKS_Errors(t *testing.T) {
+	_, err := NewJWKS(context.Background(), JWKSConfig{})
+	assert.ErrorIs(t, err, ErrJWKS)
+
+	_, err = NewJWKS(context.Background(), JWKSConfig{File: filepath.Join(t.TempDir(), "none.json")})
+	assert.ErrorIs(t, err, ErrJWKS)
+}
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	bearerPrefix = "Bearer "
+	claimsKey    = "jwtClaims"
+)
+
+var ErrMissingToken = errors.New("missing bearer token")
+
+type JWTConfig struct {
+	// Secret verifies HS256 tokens.
+	Secret []byte
+	// PublicKey verifies RS256 (*rsa.PublicKey) or ES256 (*ecdsa.PublicKey) tokens.
+	PublicKey any
+	// KeySet resolves keys by the token "kid" header and takes precedence
+	// over PublicKey for tokens that carry one.
+	KeySet KeySet
+	// Algorithms restricts accepted "alg" values. Defaults to those
+	// matching the configured keys.
+	Algorithms []string
+	Issuer     string
+	// Audience accepts tokens issued for any of the listed audiences.
+	Audience []string
+	Leeway   time.Duration
+	// NewClaims returns the claims type tokens decode into, *Claims by default.
+	NewClaims func() jwt.Claims
+}
+
+// Claims is the default claims type with the commonly used private claims.
+type Claims struct {
+	jwt.RegisteredClaims
+	Scope string   `json:"scope,omitempty"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// PrincipalClaims is implemented by claims types that map to a Principal.
+// The JWT provider attaches it to the context for authorization.
+type PrincipalClaims interface {
+	jwt.Claims
+	Principal() *Principal
+}
+
+func (c *Claims) Principal() *Principal {
+	return &Principal{
+		Id:     c.Subject,
+		Type:   PrincipalJWT,
+		Scopes: strings.Fields(c.Scope),
+		Roles:  c.Roles,
+	}
+}
+
+type jwtProvider struct {
+	config JWTConfig
+	parser *jwt.Parser
+}
+
+func NewJWTProvider(config JWTConfig) (network.AuthenticationProvider, error) {
+	if config.Secret == nil && config.PublicKey == nil && config.KeySet == nil {
+		return nil, errors.New("jwt provider requires a secret, public key or key set")
+	}
+	if config.NewClaims == nil {
+		config.NewClaims = func() jwt.Claims { return &Claims{} }
+	}
+	if len(config.Algorithms) == 0 {
+		config.Algorithms = defaultAlgorithms(config)
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(config.Algorithms),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(config.Leeway),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if len(config.Audience) > 0 {
+		options = append(options, jwt.WithAudience(config.Audience...))
+	}
+
+	return &jwtProvider{
+		config: config,
+		parser: jwt.NewParser(options...),
+	}, nil
+}
+
+func defaultAlgorithms(config JWTConfig) []string {
+	var algs []string
+	if config.Secret != nil {
+		algs = append(algs, jwt.SigningMethodHS256.Alg())
+	}
+	switch config.PublicKey.(type) {
+	case *rsa.PublicKey:
+		algs = append(algs, jwt.SigningMethodRS256.Alg())
+	case *ecdsa.PublicKey:
+		algs = append(algs, jwt.SigningMethodES256.Alg())
+	}
+	if config.KeySet != nil {
+		algs = append(algs, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+	return algs
+}
+
+func (p *jwtProvider) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token, err := BearerToken(ctx)
+		if err != nil {
+			network.SendUnauthorizedError(ctx, err.Error(), err)
+			return
+		}
+
+		claims, err := p.parse(ctx, token)
+		if err != nil {
+			msg := "invalid token"
+			if errors.Is(err, jwt.ErrTokenExpired) {
+				msg = "token expired"
+			}
+			network.SendUnauthorizedError(ctx, msg, err)
+			return
+		}
+
+		ctx.Set(claimsKey, claims)
+		if pc, ok := claims.(PrincipalClaims); ok {
+			SetPrincipal(ctx, pc.Principal())
+		}
+		ctx.Next()
+	}
+}
+
+func (p *jwtProvider) parse(ctx *gin.Context, token string) (jwt.Claims, error) {
+	claims := p.config.NewClaims()
+	_, err := p.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		return p.key(ctx, t)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (p *jwtProvider) key(ctx *gin.Context, t *jwt.Token) (any, error) {
+	if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok {
+		if p.config.Secret != nil {
+			return p.config.Secret, nil
+		}
+	}
+
+	if kid, ok := t.Header["kid"].(string); ok && p.config.KeySet != nil {
+		return p.config.KeySet.Key(ctx.Request.Context(), kid)
+	}
+
+	if p.config.PublicKey != nil {
+		return p.config.PublicKey, nil
+	}
+	return nil, fmt.Errorf("%w for alg %s", ErrKeyNotFound, t.Method.Alg())
+}
+
+// BearerToken extracts the token from the Authorization header.
+func BearerToken(ctx *gin.Context) (string, error) {
+	header := ctx.GetHeader(network.AuthorizationHeader)
+	if len(header) <= len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", ErrMissingToken
+	}
+	return strings.TrimSpace(header[len(bearerPrefix):]), nil
+}
+
+// JWTClaims returns the claims stored by the JWT provider, typed as the
+// value returned by JWTConfig.NewClaims.
+func JWTClaims[T jwt.Claims](ctx *gin.Context) (T, bool) {
+	var zero T
+	value, ok := ctx.Get(claimsKey)
+	if !ok {
+		return zero, false
+	}
+	claims, ok := value.(T)
+	return claims, ok
+}
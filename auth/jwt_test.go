@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSecret = []byte("test-secret")
+
+func signedToken(t *testing.T, method jwt.SigningMethod, key any, claims jwt.Claims, kid string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return s
+}
+
+func validClaims() *Claims {
+	return &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-1",
+			Issuer:    "goserve",
+			Audience:  jwt.ClaimStrings{"api"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Roles: []string{"admin"},
+	}
+}
+
+func bearer(token string) map[string]string {
+	return map[string]string{network.AuthorizationHeader: "Bearer " + token}
+}
+
+func claimsHandler(ctx *gin.Context) {
+	claims, ok := JWTClaims[*Claims](ctx)
+	if !ok {
+		network.SendInternalServerError(ctx, "no claims", nil)
+		return
+	}
+	network.SendSuccessMsgResponse(ctx, claims.Subject)
+}
+
+func TestJWTProvider_HS256(t *testing.T) {
+	provider, err := NewJWTProvider(JWTConfig{
+		Secret:   testSecret,
+		Issuer:   "goserve",
+		Audience: []string{"web", "api"},
+	})
+	assert.NoError(t, err)
+
+	t.Run("should accept a valid token", func(t *testing.T) {
+		token := signedToken(t, jwt.SigningMethodHS256, testSecret, validClaims(), "")
+		rr := network.MockTestAuthenticationProvider(t, provider, claimsHandler, bearer(token))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"message":"user-1"`)
+	})
+
+	t.Run("should reject a missing header", func(t *testing.T) {
+		rr := network.MockTestAuthenticationProvider(t, provider, claimsHandler, nil)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"message":"missing bearer token"`)
+	})
+
+	t.Run("should reject a non bearer header", func(t *testing.T) {
+		headers := map[string]string{network.AuthorizationHeader: "Basic abc"}
+		rr := network.MockTestAuthenticationProvider(t, provider, claimsHandler, headers)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should reject an expired token", func(t *testing.T) {
+		claims := validClaims()
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		token := signedToken(t, jwt.SigningMethodHS256, testSecret, claims, "")
+		rr := network.MockTestAuthenticationProvider(t, provider, claimsHandler, bearer(token))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"message":"token expired"`)
+	})
+
+	t.Run("should reject a token without expiry", func(t *testing.T) {
+		claims := validClaims()
+		claims.ExpiresAt = nil
+		token := signedToken(t, jwt.SigningMethodHS256, testSecret, claims, "")
+		rr := network.MockTestAuthenticationProvider(t, provider, claimsHandler, bearer(token))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should reject a wrong issuer", func(t *testing.T) {
+		claims := validClaims()
+		claims.Issuer = "other"
+		token := signedToken(t, jwt.SigningMethodHS256, testSecret, claims, "")
+		rr := network.MockTestAuthenticationProvider(t, provider, claimsHandler, bearer(token))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"message":"invalid token"`)
+	})
+
+	t.Run("should reject a wrong audience", func(t *testing.T) {
+		claims := validClaims()
+		claims.Audience = jwt.ClaimStrings{"admin"}
+		token := signedToken(t, jwt.SigningMethodHS256, testSecret, claims, "")
+		rr := network.MockTestAuthenticationProvider(t, provider, claimsHandler, bearer(token))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should reject a wrong signature", func(t *testing.T) {
+		token := signedToken(t, jwt.SigningMethodHS256, []byte("other"), validClaims(), "")
+		rr := network.MockTestAuthenticationProvider(t, provider, claimsHandler, bearer(token))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should reject an unsigned token", func(t *testing.T) {
+		token := signedToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, validClaims(), "")
+		rr := network.MockTestAuthenticationProvider(t, provider, claimsHandler, bearer(token))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestJWTProvider_Leeway(t *testing.T) {
+	provider, err := NewJWTProvider(JWTConfig{Secret: testSecret, Leeway: time.Minute})
+	assert.NoError(t, err)
+
+	claims := validClaims()
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-10 * time.Second))
+	token := signedToken(t, jwt.SigningMethodHS256, testSecret, claims, "")
+
+	rr := network.MockTestAuthenticationProvider(t, provider, claimsHandler, bearer(token))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestJWTProvider_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	provider, err := NewJWTProvider(JWTConfig{PublicKey: &key.PublicKey})
+	assert.NoError(t, err)
+
+	token := signedToken(t, jwt.SigningMethodRS256, key, validClaims(), "")
+	rr := network.MockTestAuthenticationProvider(t, provider, claimsHandler, bearer(token))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	hs := signedToken(t, jwt.SigningMethodHS256, testSecret, validClaims(), "")
+	rr = network.MockTestAuthenticationProvider(t, provider, claimsHandler, bearer(hs))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestJWTProvider_ES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	provider, err := NewJWTProvider(JWTConfig{PublicKey: &key.PublicKey})
+	assert.NoError(t, err)
+
+	token := signedToken(t, jwt.SigningMethodES256, key, validClaims(), "")
+	rr := network.MockTestAuthenticationProvider(t, provider, claimsHandler, bearer(token))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+type customClaims struct {
+	jwt.RegisteredClaims
+	TenantId string `json:"tid"`
+}
+
+func TestJWTProvider_CustomClaims(t *testing.T) {
+	provider, err := NewJWTProvider(JWTConfig{
+		Secret:    testSecret,
+		NewClaims: func() jwt.Claims { return &customClaims{} },
+	})
+	assert.NoError(t, err)
+
+	claims := &customClaims{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		TenantId:         "tenant-7",
+	}
+	token := signedToken(t, jwt.SigningMethodHS256, testSecret, claims, "")
+
+	handler := func(ctx *gin.Context) {
+		_, ok := JWTClaims[*Claims](ctx)
+		assert.False(t, ok)
+		c, ok := JWTClaims[*customClaims](ctx)
+		assert.True(t, ok)
+		network.SendSuccessMsgResponse(ctx, c.TenantId)
+	}
+
+	rr := network.MockTestAuthenticationProvider(t, provider, handler, bearer(token))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"message":"tenant-7"`)
+}
+
+func TestNewJWTProvider_RequiresKey(t *testing.T) {
+	_, err := NewJWTProvider(JWTConfig{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+const principalKey = "principal"
+
+const (
+	PrincipalJWT    = "jwt"
+	PrincipalApiKey = "apikey"
+)
+
+// Principal is the authenticated identity shared by all providers, so
+// authorization does not depend on how a request was authenticated.
+type Principal struct {
+	Id     string
+	Type   string
+	Scopes []string
+	Roles  []string
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func SetPrincipal(ctx *gin.Context, principal *Principal) {
+	ctx.Set(principalKey, principal)
+}
+
+func PrincipalFromContext(ctx *gin.Context) (*Principal, bool) {
+	value, ok := ctx.Get(principalKey)
+	if !ok {
+		return nil, false
+	}
+	principal, ok := value.(*Principal)
+	return principal, ok
+}
@@ -0,0 +1,192 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	anyOfSeparator = "|"
+	allOfSeparator = "&"
+	wildcard       = "*"
+)
+
+var ErrNoSubject = errors.New("no authenticated subject")
+
+type Subject struct {
+	Id          string
+	Roles       []string
+	Permissions []string
+}
+
+type SubjectResolver func(ctx *gin.Context) (*Subject, error)
+
+// PrincipalSubject resolves the subject from the Principal set by the JWT
+// and API key providers; scopes are treated as permissions.
+func PrincipalSubject(ctx *gin.Context) (*Subject, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil, ErrNoSubject
+	}
+	return &Subject{
+		Id:          principal.Id,
+		Roles:       principal.Roles,
+		Permissions: principal.Scopes,
+	}, nil
+}
+
+type RBACConfig struct {
+	// Inherits maps a role to the roles it includes, e.g. "admin": {"editor"}.
+	Inherits map[string][]string
+	// Permissions maps a role to the permissions it grants directly.
+	Permissions map[string][]string
+	// Resolver defaults to PrincipalSubject.
+	Resolver SubjectResolver
+}
+
+type rbacProvider struct {
+	resolver    SubjectResolver
+	roles       map[string]map[string]bool
+	permissions map[string]map[string]bool
+}
+
+// NewRBACProvider returns an AuthorizationProvider whose Middleware params
+// are requirements: a param containing ":" is a permission, otherwise a
+// role. The request passes if any param is satisfied; a single param can
+// combine requirements with AnyOf and AllOf.
+func NewRBACProvider(config RBACConfig) network.AuthorizationProvider {
+	if config.Resolver == nil {
+		config.Resolver = PrincipalSubject
+	}
+
+	p := &rbacProvider{
+		resolver:    config.Resolver,
+		roles:       make(map[string]map[string]bool),
+		permissions: make(map[string]map[string]bool),
+	}
+
+	names := make(map[string]bool)
+	for role, inherits := range config.Inherits {
+		names[role] = true
+		for _, r := range inherits {
+			names[r] = true
+		}
+	}
+	for role := range config.Permissions {
+		names[role] = true
+	}
+
+	for role := range names {
+		implied := make(map[string]bool)
+		expandRole(role, config.Inherits, implied)
+		granted := make(map[string]bool)
+		for r := range implied {
+			for _, perm := range config.Permissions[r] {
+				granted[perm] = true
+			}
+		}
+		p.roles[role] = implied
+		p.permissions[role] = granted
+	}
+	return p
+}
+
+func expandRole(role string, inherits map[string][]string, seen map[string]bool) {
+	if seen[role] {
+		return
+	}
+	seen[role] = true
+	for _, r := range inherits[role] {
+		expandRole(r, inherits, seen)
+	}
+}
+
+// AnyOf combines requirements into a single param satisfied by any of them.
+func AnyOf(requirements ...string) string {
+	return strings.Join(requirements, anyOfSeparator)
+}
+
+// AllOf combines requirements into a single param satisfied only by all of them.
+func AllOf(requirements ...string) string {
+	return strings.Join(requirements, allOfSeparator)
+}
+
+// Middleware panics on an empty requirement, which no subject could
+// satisfy, so a typo can not lock a route for everyone.
+func (p *rbacProvider) Middleware(params ...string) gin.HandlerFunc {
+	var alternatives [][]string
+	for _, param := range params {
+		for _, alt := range strings.Split(param, anyOfSeparator) {
+			requirements := strings.Split(alt, allOfSeparator)
+			for _, req := range requirements {
+				if strings.TrimSpace(req) == "" {
+					panic(fmt.Sprintf("empty requirement in authorization param %q", param))
+				}
+			}
+			alternatives = append(alternatives, requirements)
+		}
+	}
+
+	return func(ctx *gin.Context) {
+		subject, err := p.resolver(ctx)
+		if err != nil || subject == nil {
+			network.SendUnauthorizedError(ctx, "unauthenticated", err)
+			return
+		}
+
+		if len(alternatives) > 0 && !p.authorize(subject, alternatives) {
+			network.SendForbiddenError(ctx, "permission denied", nil)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func (p *rbacProvider) authorize(subject *Subject, alternatives [][]string) bool {
+	roles := make(map[string]bool)
+	permissions := make(map[string]bool)
+	for _, perm := range subject.Permissions {
+		permissions[perm] = true
+	}
+	for _, role := range subject.Roles {
+		roles[role] = true
+		for r := range p.roles[role] {
+			roles[r] = true
+		}
+		for perm := range p.permissions[role] {
+			permissions[perm] = true
+		}
+	}
+
+	for _, requirements := range alternatives {
+		satisfied := true
+		for _, req := range requirements {
+			if !satisfies(req, roles, permissions) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+func satisfies(requirement string, roles, permissions map[string]bool) bool {
+	requirement = strings.TrimSpace(requirement)
+	if !strings.Contains(requirement, ":") {
+		return roles[requirement]
+	}
+	if permissions[requirement] || permissions[wildcard] {
+		return true
+	}
+	// "blog:*" grants every action on blog
+	resource := requirement[:strings.LastIndex(requirement, ":")]
+	return permissions[resource+":"+wildcard]
+}
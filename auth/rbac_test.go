@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type subjectAuthentication struct {
+	subject *Subject
+}
+
+func (a *subjectAuthentication) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		SetPrincipal(ctx, &Principal{Id: a.subject.Id, Roles: a.subject.Roles, Scopes: a.subject.Permissions})
+		ctx.Next()
+	}
+}
+
+func authorize(t *testing.T, subject *Subject, requirement string) int {
+	provider := NewRBACProvider(RBACConfig{
+		Inherits: map[string][]string{
+			"admin":  {"editor"},
+			"editor": {"viewer"},
+		},
+		Permissions: map[string][]string{
+			"viewer": {"blog:read"},
+			"editor": {"blog:write"},
+			"admin":  {"user:*"},
+		},
+	})
+	rr := network.MockTestAuthorizationProvider(t, requirement, &subjectAuthentication{subject}, provider,
+		network.MockSuccessMsgHandler("ok"), nil)
+	return rr.Code
+}
+
+func TestRBACProvider_Roles(t *testing.T) {
+	admin := &Subject{Id: "1", Roles: []string{"admin"}}
+	viewer := &Subject{Id: "2", Roles: []string{"viewer"}}
+
+	assert.Equal(t, http.StatusOK, authorize(t, admin, "admin"))
+	assert.Equal(t, http.StatusOK, authorize(t, admin, "viewer"))
+	assert.Equal(t, http.StatusForbidden, authorize(t, viewer, "editor"))
+	assert.Equal(t, http.StatusOK, authorize(t, viewer, ""))
+}
+
+func TestRBACProvider_Permissions(t *testing.T) {
+	admin := &Subject{Id: "1", Roles: []string{"admin"}}
+	editor := &Subject{Id: "2", Roles: []string{"editor"}}
+	client := &Subject{Id: "3", Permissions: []string{"blog:read"}}
+
+	assert.Equal(t, http.StatusOK, authorize(t, editor, "blog:read"))
+	assert.Equal(t, http.StatusOK, authorize(t, editor, "blog:write"))
+	assert.Equal(t, http.StatusForbidden, authorize(t, editor, "user:delete"))
+	assert.Equal(t, http.StatusOK, authorize(t, admin, "user:delete"))
+	assert.Equal(t, http.StatusOK, authorize(t, client, "blog:read"))
+	assert.Equal(t, http.StatusForbidden, authorize(t, client, "blog:write"))
+}
+
+func TestRBACProvider_AnyOfAllOf(t *testing.T) {
+	editor := &Subject{Id: "2", Roles: []string{"editor"}}
+	client := &Subject{Id: "3", Permissions: []string{"blog:read"}}
+
+	assert.Equal(t, http.StatusOK, authorize(t, client, AnyOf("admin", "blog:read")))
+	assert.Equal(t, http.StatusForbidden, authorize(t, client, AllOf("blog:read", "blog:write")))
+	assert.Equal(t, http.StatusOK, authorize(t, editor, AllOf("blog:read", "blog:write")))
+	assert.Equal(t, http.StatusOK, authorize(t, client, AnyOf(AllOf("editor", "blog:write"), "blog:read")))
+}
+
+func serve(handlers ...gin.HandlerFunc) int {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", handlers...)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rr.Code
+}
+
+func TestRBACProvider_MultipleParams(t *testing.T) {
+	provider := NewRBACProvider(RBACConfig{})
+	auth := &subjectAuthentication{&Subject{Id: "1", Roles: []string{"writer"}}}
+
+	code := serve(auth.Middleware(), provider.Middleware("admin", "writer"), network.MockSuccessMsgHandler("ok"))
+	assert.Equal(t, http.StatusOK, code)
+
+	code = serve(auth.Middleware(), provider.Middleware("admin", "reader"), network.MockSuccessMsgHandler("ok"))
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func TestRBACProvider_EmptyRequirement(t *testing.T) {
+	provider := NewRBACProvider(RBACConfig{})
+	for _, param := range []string{"", " ", AnyOf("admin", ""), AllOf("admin", "")} {
+		assert.Panics(t, func() { provider.Middleware(param) }, param)
+	}
+	assert.NotPanics(t, func() { provider.Middleware() })
+}
+
+func TestRBACProvider_NoSubject(t *testing.T) {
+	provider := NewRBACProvider(RBACConfig{})
+	code := serve(provider.Middleware("admin"), network.MockSuccessMsgHandler("ok"))
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	resolverErr := errors.New("no session")
+	provider = NewRBACProvider(RBACConfig{
+		Resolver: func(ctx *gin.Context) (*Subject, error) { return nil, resolverErr },
+	})
+	auth := &subjectAuthentication{&Subject{Id: "1", Roles: []string{"admin"}}}
+	code = serve(auth.Middleware(), provider.Middleware("admin"), network.MockSuccessMsgHandler("ok"))
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestPrincipalSubject(t *testing.T) {
+	ctx, _ := gin.CreateTestContext(nil)
+	_, err := PrincipalSubject(ctx)
+	assert.ErrorIs(t, err, ErrNoSubject)
+
+	SetPrincipal(ctx, &Principal{Id: "1", Roles: []string{"admin"}, Scopes: []string{"blog:read"}})
+	subject, err := PrincipalSubject(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, subject.Roles)
+	assert.Equal(t, []string{"blog:read"}, subject.Permissions)
+}
@@ -0,0 +1,81 @@
+package coredto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+func EmptyCursor() *Cursor {
+	return &Cursor{}
+}
+
+// Cursor carries an opaque keyset position. The position is signed so
+// clients cannot forge one pointing anywhere else.
+type Cursor struct {
+	Cursor string `form:"cursor" validate:"omitempty,max=2048"`
+	Limit  int64  `form:"limit" binding:"required" validate:"required,min=1,max=1000"`
+}
+
+func (d *Cursor) GetValue() *Cursor {
+	return d
+}
+
+func (d *Cursor) ValidateErrors(errs validator.ValidationErrors) ([]string, error) {
+	var msgs []string
+	for _, err := range errs {
+		switch err.Tag() {
+		case "required":
+			msgs = append(msgs, fmt.Sprintf("%s is required", err.Field()))
+		case "min":
+			msgs = append(msgs, fmt.Sprintf("%s must be min %s", err.Field(), err.Param()))
+		case "max":
+			msgs = append(msgs, fmt.Sprintf("%s must be max %s", err.Field(), err.Param()))
+		default:
+			msgs = append(msgs, fmt.Sprintf("%s is invalid", err.Field()))
+		}
+	}
+	return msgs, nil
+}
+
+// Payload verifies the cursor and returns the position it encodes, nil
+// when no cursor was sent, i.e. for the first page.
+func (d *Cursor) Payload(secret []byte) ([]byte, error) {
+	if d.Cursor == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(d.Cursor)
+	if err != nil || len(raw) <= sha256.Size {
+		return nil, ErrInvalidCursor
+	}
+
+	payload, sig := raw[:len(raw)-sha256.Size], raw[len(raw)-sha256.Size:]
+	if !hmac.Equal(sig, sign(secret, payload)) {
+		return nil, ErrInvalidCursor
+	}
+	return payload, nil
+}
+
+// EncodeCursor signs payload into an opaque cursor, "" for a nil payload
+// so the last page carries no next cursor.
+func EncodeCursor(secret []byte, payload []byte) string {
+	if payload == nil {
+		return ""
+	}
+	raw := make([]byte, 0, len(payload)+sha256.Size)
+	raw = append(append(raw, payload...), sign(secret, payload)...)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func sign(secret []byte, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
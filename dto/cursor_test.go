@@ -0,0 +1,70 @@
+package coredto
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+var cursorSecret = []byte("cursor-secret")
+
+func TestCursor_Payload(t *testing.T) {
+	t.Run("should round trip payload", func(t *testing.T) {
+		encoded := EncodeCursor(cursorSecret, []byte(`{"id":42}`))
+		cursor := &Cursor{Cursor: encoded, Limit: 10}
+
+		payload, err := cursor.Payload(cursorSecret)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(`{"id":42}`), payload)
+	})
+
+	t.Run("should return nil for first page", func(t *testing.T) {
+		payload, err := EmptyCursor().Payload(cursorSecret)
+		assert.NoError(t, err)
+		assert.Nil(t, payload)
+		assert.Equal(t, "", EncodeCursor(cursorSecret, nil))
+	})
+
+	t.Run("should reject wrong secret", func(t *testing.T) {
+		cursor := &Cursor{Cursor: EncodeCursor(cursorSecret, []byte("position"))}
+		_, err := cursor.Payload([]byte("other"))
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("should reject tampered cursor", func(t *testing.T) {
+		encoded := []byte(EncodeCursor(cursorSecret, []byte("position")))
+		encoded[0] ^= 1
+		cursor := &Cursor{Cursor: string(encoded)}
+		_, err := cursor.Payload(cursorSecret)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("should reject malformed cursor", func(t *testing.T) {
+		for _, c := range []string{"not base64!", "c2hvcnQ"} {
+			_, err := (&Cursor{Cursor: c}).Payload(cursorSecret)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		}
+	})
+}
+
+func TestEncodeCursor_DoesNotModifyPayload(t *testing.T) {
+	payload := make([]byte, 3, 64)
+	copy(payload, "abc")
+	EncodeCursor(cursorSecret, payload)
+	assert.Equal(t, []byte("abc"), payload[:3:3])
+	assert.Equal(t, byte(0), payload[:4][3])
+}
+
+func TestCursor_Validation(t *testing.T) {
+	v := validator.New()
+
+	assert.NoError(t, v.Struct(&Cursor{Limit: 10}))
+
+	err := v.Struct(&Cursor{Limit: 0})
+	assert.Error(t, err)
+
+	msgs, err := (&Cursor{}).ValidateErrors(err.(validator.ValidationErrors))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Limit is required"}, msgs)
+}
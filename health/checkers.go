@@ -0,0 +1,56 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/micro"
+	"github.com/afteracademy/goserve/v2/mongo"
+	"github.com/afteracademy/goserve/v2/postgres"
+	"github.com/afteracademy/goserve/v2/redis"
+	"github.com/nats-io/nats.go"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func NewMongoChecker(db mongo.Database, timeout time.Duration) Checker {
+	return NewChecker("mongo", timeout, func(ctx context.Context) error {
+		instance := db.GetInstance()
+		if instance.Database == nil {
+			return ErrNotConnected
+		}
+		return instance.Client().Ping(ctx, readpref.Primary())
+	})
+}
+
+func NewPostgresChecker(db postgres.Database, timeout time.Duration) Checker {
+	return NewChecker("postgres", timeout, func(ctx context.Context) error {
+		pool := db.Pool()
+		if pool == nil {
+			return ErrNotConnected
+		}
+		return pool.Ping(ctx)
+	})
+}
+
+func NewRedisChecker(store redis.Store, timeout time.Duration) Checker {
+	return NewChecker("redis", timeout, func(ctx context.Context) error {
+		return store.GetInstance().Ping(ctx).Err()
+	})
+}
+
+func NewNatsChecker(client micro.NatsClient, timeout time.Duration) Checker {
+	return NewChecker("nats", timeout, func(ctx context.Context) error {
+		instance := client.GetInstance()
+		if instance.Conn == nil {
+			return ErrNotConnected
+		}
+		if status := instance.Conn.Status(); status != nats.CONNECTED {
+			return fmt.Errorf("connection is %s", status)
+		}
+		if instance.Service != nil && instance.Service.Stopped() {
+			return fmt.Errorf("service %s is stopped", instance.Service.Info().Name)
+		}
+		return nil
+	})
+}
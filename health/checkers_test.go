@@ -0,0 +1,63 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/micro"
+	"github.com/afteracademy/goserve/v2/mongo"
+	"github.com/afteracademy/goserve/v2/postgres"
+	"github.com/afteracademy/goserve/v2/redis"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/nats-io/nats-server/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRedisChecker(t *testing.T) {
+	mr := miniredis.RunT(t)
+	store := redis.NewStore(context.Background(), &redis.Config{
+		Host:   mr.Host(),
+		Port:   uint16(mr.Server().Addr().Port),
+		Logger: logger.Nop(),
+	})
+	defer store.Disconnect()
+
+	checker := NewRedisChecker(store, time.Second)
+	assert.Equal(t, "redis", checker.Name())
+	assert.NoError(t, checker.Check(context.Background()))
+
+	mr.Close()
+	assert.Error(t, checker.Check(context.Background()))
+}
+
+func TestNewNatsChecker(t *testing.T) {
+	opts := test.DefaultTestOptions
+	opts.Port = -1
+	s := test.RunServer(&opts)
+	defer s.Shutdown()
+
+	client, err := micro.ConnectNatsClient(context.Background(), &micro.Config{
+		NatsUrl:            s.ClientURL(),
+		NatsServiceName:    "health",
+		NatsServiceVersion: "1.0.0",
+		Timeout:            time.Second,
+		Logger:             logger.Nop(),
+	})
+	assert.NoError(t, err)
+
+	checker := NewNatsChecker(client, time.Second)
+	assert.NoError(t, checker.Check(context.Background()))
+
+	client.Disconnect()
+	assert.Error(t, checker.Check(context.Background()))
+}
+
+func TestCheckers_NotConnected(t *testing.T) {
+	mongoChecker := NewMongoChecker(mongo.NewDatabase(context.Background(), mongo.DbConfig{}), 0)
+	assert.ErrorIs(t, mongoChecker.Check(context.Background()), ErrNotConnected)
+
+	postgresChecker := NewPostgresChecker(postgres.NewDatabase(context.Background(), postgres.DbConfig{}), 0)
+	assert.ErrorIs(t, postgresChecker.Check(context.Background()), ErrNotConnected)
+}
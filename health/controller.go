@@ -0,0 +1,55 @@
+package health
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+)
+
+type Config struct {
+	// Path is the controller base path, "/" by default.
+	Path string
+	// Timeout applies to checkers without their own timeout, 2s by default.
+	Timeout time.Duration
+	// Liveness checkers run on /livez. Keep them to in-process checks; a
+	// failing dependency should not get the pod restarted.
+	Liveness []Checker
+	// Readiness checkers run on /readyz, typically the datastore checkers.
+	Readiness []Checker
+}
+
+// unhealthyCode is the failure ResCode of the network responses.
+const unhealthyCode network.ResCode = "10001"
+
+type controller struct {
+	network.Controller
+	config Config
+}
+
+func NewController(config Config) network.Controller {
+	if config.Path == "" {
+		config.Path = "/"
+	}
+	return &controller{
+		Controller: network.NewController(config.Path, nil, nil),
+		config:     config,
+	}
+}
+
+func (c *controller) MountRoutes(group *gin.RouterGroup) {
+	group.GET("/livez", c.probeHandler(c.config.Liveness))
+	group.GET("/readyz", c.probeHandler(c.config.Readiness))
+}
+
+func (c *controller) probeHandler(checkers []Checker) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		report := Run(ctx.Request.Context(), c.config.Timeout, checkers...)
+		if report.Healthy() {
+			network.SendSuccessDataResponse(ctx, "healthy", report)
+			return
+		}
+		network.SendCustomResponse(ctx, unhealthyCode, http.StatusServiceUnavailable, "unhealthy", report)
+	}
+}
@@ -0,0 +1,46 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController(t *testing.T) {
+	up := NewChecker("up", 0, func(ctx context.Context) error { return nil })
+	down := NewChecker("down", 0, func(ctx context.Context) error { return errors.New("unreachable") })
+
+	c := NewController(Config{
+		Path:      "/health",
+		Liveness:  []Checker{up},
+		Readiness: []Checker{up, down},
+	})
+	assert.Equal(t, "/health", c.Path())
+
+	t.Run("livez should succeed", func(t *testing.T) {
+		rr := network.MockTestController(t, http.MethodGet, "/health/livez", "", c)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"code":"10000"`)
+		assert.Contains(t, rr.Body.String(), `"status":"up"`)
+	})
+
+	t.Run("readyz should fail with 503", func(t *testing.T) {
+		rr := network.MockTestController(t, http.MethodGet, "/health/readyz", "", c)
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"code":"10001"`)
+		assert.Contains(t, rr.Body.String(), `"message":"unhealthy"`)
+		assert.Contains(t, rr.Body.String(), `"error":"unreachable"`)
+	})
+}
+
+func TestNewController_DefaultPath(t *testing.T) {
+	c := NewController(Config{})
+	assert.Equal(t, "/", c.Path())
+
+	rr := network.MockTestController(t, http.MethodGet, "/readyz", "", c)
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
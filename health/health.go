@@ -0,0 +1,129 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	defaultTimeout = 2 * time.Second
+)
+
+var ErrNotConnected = errors.New("not connected")
+
+type Checker interface {
+	Name() string
+	// Timeout bounds a single Check. Zero means the Config default.
+	Timeout() time.Duration
+	Check(ctx context.Context) error
+}
+
+type CheckResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+type Report struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+func (r *Report) Healthy() bool {
+	return r.Status == StatusUp
+}
+
+type checker struct {
+	name    string
+	timeout time.Duration
+	check   func(ctx context.Context) error
+}
+
+func NewChecker(name string, timeout time.Duration, check func(ctx context.Context) error) Checker {
+	return &checker{
+		name:    name,
+		timeout: timeout,
+		check:   check,
+	}
+}
+
+func (c *checker) Name() string {
+	return c.name
+}
+
+func (c *checker) Timeout() time.Duration {
+	return c.timeout
+}
+
+func (c *checker) Check(ctx context.Context) error {
+	return c.check(ctx)
+}
+
+// Run executes all checkers concurrently, each bounded by its own timeout
+// or fallback when it has none. Results keep the order of checkers.
+func Run(ctx context.Context, fallback time.Duration, checkers ...Checker) *Report {
+	if fallback <= 0 {
+		fallback = defaultTimeout
+	}
+
+	report := &Report{Status: StatusUp, Checks: make([]CheckResult, len(checkers))}
+
+	var wg sync.WaitGroup
+	for i, c := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = runCheck(ctx, fallback, c)
+		}()
+	}
+	wg.Wait()
+
+	for _, r := range report.Checks {
+		if r.Status != StatusUp {
+			report.Status = StatusDown
+			break
+		}
+	}
+	return report
+}
+
+func runCheck(ctx context.Context, fallback time.Duration, c Checker) CheckResult {
+	timeout := c.Timeout()
+	if timeout <= 0 {
+		timeout = fallback
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// a checker ignoring ctx must not hold the probe past its timeout
+		err = ctx.Err()
+	}
+
+	result := CheckResult{
+		Name:     c.Name(),
+		Status:   StatusUp,
+		Duration: time.Since(start).String(),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,72 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("should report up when all checks pass", func(t *testing.T) {
+		report := Run(context.Background(), time.Second,
+			NewChecker("a", 0, func(ctx context.Context) error { return nil }),
+			NewChecker("b", 0, func(ctx context.Context) error { return nil }),
+		)
+
+		assert.True(t, report.Healthy())
+		assert.Len(t, report.Checks, 2)
+		assert.Equal(t, "a", report.Checks[0].Name)
+		assert.Equal(t, "b", report.Checks[1].Name)
+	})
+
+	t.Run("should report down when any check fails", func(t *testing.T) {
+		report := Run(context.Background(), time.Second,
+			NewChecker("a", 0, func(ctx context.Context) error { return nil }),
+			NewChecker("b", 0, func(ctx context.Context) error { return errors.New("boom") }),
+		)
+
+		assert.False(t, report.Healthy())
+		assert.Equal(t, StatusUp, report.Checks[0].Status)
+		assert.Equal(t, StatusDown, report.Checks[1].Status)
+		assert.Equal(t, "boom", report.Checks[1].Error)
+	})
+
+	t.Run("should report up with no checks", func(t *testing.T) {
+		report := Run(context.Background(), 0)
+		assert.True(t, report.Healthy())
+		assert.Empty(t, report.Checks)
+	})
+
+	t.Run("should apply per check timeout", func(t *testing.T) {
+		start := time.Now()
+		report := Run(context.Background(), time.Minute,
+			NewChecker("slow", 50*time.Millisecond, func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+		)
+
+		assert.Less(t, time.Since(start), time.Second)
+		assert.False(t, report.Healthy())
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks[0].Error)
+	})
+
+	t.Run("should not wait for checks ignoring the context", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		start := time.Now()
+		report := Run(context.Background(), 50*time.Millisecond,
+			NewChecker("stuck", 0, func(ctx context.Context) error {
+				<-release
+				return nil
+			}),
+		)
+
+		assert.Less(t, time.Since(start), time.Second)
+		assert.False(t, report.Healthy())
+	})
+}
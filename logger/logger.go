@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"io"
+	"log/slog"
+)
+
+// Logger is satisfied by *slog.Logger, so any slog handler can be plugged in.
+// Args are alternating key-value pairs or slog.Attr values.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+func Default() Logger {
+	return slog.Default()
+}
+
+func NewJSON(w io.Writer, level slog.Level) Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+func NewText(w io.Writer, level slog.Level) Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+func Nop() Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// Or returns l, falling back to Default when l is nil.
+func Or(l Logger) Logger {
+	if l == nil {
+		return Default()
+	}
+	return l
+}
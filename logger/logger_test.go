@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJSON(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewJSON(&buf, slog.LevelInfo)
+
+	l.Info("connected", "service", "mongo", "attempt", 2)
+	l.Debug("hidden")
+
+	var entry map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "connected", entry["msg"])
+	assert.Equal(t, "INFO", entry["level"])
+	assert.Equal(t, "mongo", entry["service"])
+	assert.Equal(t, float64(2), entry["attempt"])
+	assert.NotContains(t, buf.String(), "hidden")
+}
+
+func TestNewText(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewText(&buf, slog.LevelDebug)
+
+	l.Debug("indexing", "collection", "blogs")
+
+	assert.Contains(t, buf.String(), "level=DEBUG")
+	assert.Contains(t, buf.String(), "collection=blogs")
+}
+
+func TestOr(t *testing.T) {
+	assert.Equal(t, Default(), Or(nil))
+
+	l := Nop()
+	assert.Equal(t, l, Or(l))
+}
@@ -0,0 +1,186 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type Config struct {
+	Namespace string
+	// Buckets for the latency histograms in seconds, prometheus.DefBuckets when empty.
+	Buckets []float64
+	// Registry to register the collectors on, a new registry with the Go and
+	// process collectors when nil.
+	Registry *prometheus.Registry
+}
+
+// PoolStats is a snapshot of a database connection pool.
+type PoolStats struct {
+	Open  int64
+	InUse int64
+	Idle  int64
+	Max   int64
+}
+
+// Metrics holds the framework collectors. All methods are safe to call on a
+// nil *Metrics, so instrumentation stays optional.
+type Metrics struct {
+	registry      *prometheus.Registry
+	httpRequests  *prometheus.CounterVec
+	httpDuration  *prometheus.HistogramVec
+	apiErrors     *prometheus.CounterVec
+	natsDuration  *prometheus.HistogramVec
+	natsTimeouts  *prometheus.CounterVec
+	cacheRequests *prometheus.CounterVec
+	namespace     string
+}
+
+func New(config Config) *Metrics {
+	registry := config.Registry
+	if registry == nil {
+		registry = prometheus.NewRegistry()
+		registry.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		)
+	}
+
+	buckets := config.Buckets
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+
+	m := &Metrics{
+		registry: registry,
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: config.Namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests by route template, method and status.",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: config.Namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route template, method and status.",
+			Buckets:   buckets,
+		}, []string{"route", "method", "status"}),
+		apiErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: config.Namespace,
+			Name:      "api_errors_total",
+			Help:      "Number of ApiErrors sent by status code.",
+		}, []string{"code"}),
+		natsDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: config.Namespace,
+			Name:      "nats_request_duration_seconds",
+			Help:      "NATS request latency by subject and outcome.",
+			Buckets:   buckets,
+		}, []string{"subject", "outcome"}),
+		natsTimeouts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: config.Namespace,
+			Name:      "nats_request_timeouts_total",
+			Help:      "Number of NATS requests that timed out by subject.",
+		}, []string{"subject"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: config.Namespace,
+			Name:      "cache_requests_total",
+			Help:      "Number of cache reads by result.",
+		}, []string{"result"}),
+		namespace: config.Namespace,
+	}
+
+	registry.MustRegister(
+		m.httpRequests,
+		m.httpDuration,
+		m.apiErrors,
+		m.natsDuration,
+		m.natsTimeouts,
+		m.cacheRequests,
+	)
+
+	return m
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObserveRequest(route, method string, status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(route, method, code).Inc()
+	m.httpDuration.WithLabelValues(route, method, code).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveApiError(code int) {
+	if m == nil {
+		return
+	}
+	m.apiErrors.WithLabelValues(strconv.Itoa(code)).Inc()
+}
+
+func (m *Metrics) ObserveNatsRequest(subject string, duration time.Duration, err error, timeout bool) {
+	if m == nil {
+		return
+	}
+	outcome := "success"
+	if timeout {
+		outcome = "timeout"
+		m.natsTimeouts.WithLabelValues(subject).Inc()
+	} else if err != nil {
+		outcome = "error"
+	}
+	m.natsDuration.WithLabelValues(subject, outcome).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveCache(hit bool) {
+	if m == nil {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheRequests.WithLabelValues(result).Inc()
+}
+
+// RegisterPool exposes the stats of a connection pool, read on every scrape.
+func (m *Metrics) RegisterPool(system string, stats func() PoolStats) error {
+	if m == nil {
+		return nil
+	}
+	return m.registry.Register(&poolCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(m.namespace, "", "db_pool_connections"),
+			"Database connection pool size by system and state.",
+			[]string{"state"}, prometheus.Labels{"system": system},
+		),
+		stats: stats,
+	})
+}
+
+type poolCollector struct {
+	desc  *prometheus.Desc
+	stats func() PoolStats
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stats()
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(s.Open), "open")
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(s.InUse), "in_use")
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(s.Idle), "idle")
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(s.Max), "max")
+}
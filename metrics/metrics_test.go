@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_NilIsNoop(t *testing.T) {
+	var m *Metrics
+	assert.NotPanics(t, func() {
+		m.ObserveRequest("/", "GET", 200, time.Millisecond)
+		m.ObserveApiError(400)
+		m.ObserveNatsRequest("a.b", time.Millisecond, nil, false)
+		m.ObserveCache(true)
+		assert.NoError(t, m.RegisterPool("mongodb", func() PoolStats { return PoolStats{} }))
+	})
+}
+
+func TestMetrics_Observe(t *testing.T) {
+	m := New(Config{Namespace: "test", Registry: prometheus.NewRegistry()})
+
+	m.ObserveRequest("/blogs/:id", "GET", 200, 10*time.Millisecond)
+	m.ObserveRequest("/blogs/:id", "GET", 200, 20*time.Millisecond)
+	m.ObserveApiError(404)
+	m.ObserveNatsRequest("blog.get", time.Millisecond, errors.New("timeout"), true)
+	m.ObserveCache(true)
+	m.ObserveCache(false)
+	m.ObserveCache(false)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.httpRequests.WithLabelValues("/blogs/:id", "GET", "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.apiErrors.WithLabelValues("404")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.natsTimeouts.WithLabelValues("blog.get")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.cacheRequests.WithLabelValues("hit")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.cacheRequests.WithLabelValues("miss")))
+}
+
+func TestMetrics_RegisterPool(t *testing.T) {
+	m := New(Config{Namespace: "test", Registry: prometheus.NewRegistry()})
+
+	assert.NoError(t, m.RegisterPool("mongodb", func() PoolStats {
+		return PoolStats{Open: 5, InUse: 2, Idle: 3, Max: 10}
+	}))
+	assert.NoError(t, m.RegisterPool("postgresql", func() PoolStats {
+		return PoolStats{Open: 1}
+	}))
+
+	count, err := testutil.GatherAndCount(m.Registry(), "test_db_pool_connections")
+	assert.NoError(t, err)
+	assert.Equal(t, 8, count)
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := New(Config{Namespace: "test"})
+	m.ObserveApiError(500)
+
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `test_api_errors_total{code="500"} 1`)
+	assert.Contains(t, rr.Body.String(), "go_goroutines")
+}
@@ -1,11 +1,18 @@
 package micro
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/metrics"
+	"github.com/afteracademy/goserve/v2/tracing"
+	"github.com/afteracademy/goserve/v2/utility"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/micro"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Config struct {
@@ -13,10 +20,20 @@ type Config struct {
 	NatsServiceName    string
 	NatsServiceVersion string
 	Timeout            time.Duration
+	Retry              utility.RetryConfig
+	Logger             logger.Logger
+	TracerProvider     trace.TracerProvider
+	Metrics            *metrics.Metrics
 }
 
+var (
+	ErrConnect    = errors.New("connection to nats failed")
+	ErrAddService = errors.New("adding nats service failed")
+)
+
 type NatsClient interface {
 	GetInstance() *natsClient
+	Drain(ctx context.Context) error
 	Disconnect()
 }
 
@@ -24,39 +41,102 @@ type natsClient struct {
 	Conn    *nats.Conn
 	Service micro.Service
 	Timeout time.Duration
+	logger  logger.Logger
+
+	tracerProvider trace.TracerProvider
+	metrics        *metrics.Metrics
 }
 
 func (n *natsClient) GetInstance() *natsClient {
 	return n
 }
 
+func (n *natsClient) Logger() logger.Logger {
+	return logger.Or(n.logger)
+}
+
+func (n *natsClient) tracer() trace.Tracer {
+	return tracing.Tracer(n.tracerProvider)
+}
+
+// Drain stops the service so no new requests are accepted and then drains
+// the connection, waiting for in-flight messages until ctx is done.
+// It matches network.LifecycleHook so it can be passed to OnShutdown.
+func (n *natsClient) Drain(ctx context.Context) error {
+	n.Logger().Info("draining nats")
+	if n.Service != nil && !n.Service.Stopped() {
+		if err := n.Service.Stop(); err != nil {
+			return err
+		}
+	}
+	if err := n.Conn.Drain(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for !n.Conn.IsClosed() {
+		select {
+		case <-ctx.Done():
+			n.Conn.Close()
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	n.Logger().Info("drained nats")
+	return nil
+}
+
 func (n *natsClient) Disconnect() {
-	fmt.Println("disconnecting nats..")
+	n.Logger().Info("disconnecting nats")
 	n.Conn.Close()
-	fmt.Println("disconnected nats")
+	n.Logger().Info("disconnected nats")
 }
 
 func NewNatsClient(config *Config) NatsClient {
-	fmt.Println("connecting to nats..")
-
-	nc, err := nats.Connect(config.NatsUrl)
+	client, err := ConnectNatsClient(context.Background(), config)
 	if err != nil {
 		panic(err)
 	}
+	return client
+}
+
+func ConnectNatsClient(ctx context.Context, config *Config) (NatsClient, error) {
+	log := logger.Or(config.Logger)
+	log.Info("connecting to nats", "url", config.NatsUrl)
+
+	var nc *nats.Conn
+	err := utility.Retry(ctx, config.Retry, func(ctx context.Context) error {
+		conn, err := nats.Connect(config.NatsUrl)
+		if err != nil {
+			log.Warn("nats connection attempt failed", "error", err)
+			return fmt.Errorf("%w: %w", ErrConnect, err)
+		}
+		nc = conn
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	srv, err := micro.AddService(nc, micro.Config{
 		Name:    config.NatsServiceName,
 		Version: config.NatsServiceVersion,
 	})
 	if err != nil {
-		panic(err)
+		nc.Close()
+		return nil, fmt.Errorf("%w: %w", ErrAddService, err)
 	}
 
-	fmt.Println("connected to nats")
+	log.Info("connected to nats", "service", config.NatsServiceName, "version", config.NatsServiceVersion)
 
 	return &natsClient{
 		Conn:    nc,
 		Service: srv,
 		Timeout: config.Timeout,
-	}
+		logger:  log,
+
+		tracerProvider: config.TracerProvider,
+		metrics:        config.Metrics,
+	}, nil
 }
@@ -1,9 +1,11 @@
 package micro
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/afteracademy/goserve/v2/utility"
 	"github.com/nats-io/nats-server/v2/server"
 	"github.com/nats-io/nats-server/v2/test"
 	"github.com/stretchr/testify/assert"
@@ -118,3 +120,83 @@ func TestNatsClient_Disconnect(t *testing.T) {
 		assert.True(t, instance.Conn.IsClosed())
 	})
 }
+
+func TestNatsClient_Drain(t *testing.T) {
+	t.Run("should stop service and close connection", func(t *testing.T) {
+		s := RunNatsServerOnPort(t, -1)
+		defer s.Shutdown()
+
+		config := &Config{
+			NatsUrl:            s.ClientURL(),
+			NatsServiceName:    "drain-test-service",
+			NatsServiceVersion: "1.0.0",
+			Timeout:            2 * time.Second,
+		}
+
+		client := NewNatsClient(config)
+		instance := client.GetInstance()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := client.Drain(ctx)
+		assert.NoError(t, err)
+		assert.True(t, instance.Service.Stopped())
+		assert.True(t, instance.Conn.IsClosed())
+	})
+}
+
+func TestConnectNatsClient(t *testing.T) {
+	t.Run("should return typed error with invalid NATS URL", func(t *testing.T) {
+		config := &Config{
+			NatsUrl:            "nats://localhost:4223", // Invalid/non-running port
+			NatsServiceName:    "test-service",
+			NatsServiceVersion: "1.0.0",
+			Timeout:            2 * time.Second,
+			Retry:              utility.RetryConfig{Attempts: 2, Delay: 10 * time.Millisecond},
+		}
+
+		client, err := ConnectNatsClient(context.Background(), config)
+		assert.Nil(t, client)
+		assert.ErrorIs(t, err, ErrConnect)
+
+		var retryErr *utility.RetryError
+		assert.ErrorAs(t, err, &retryErr)
+		assert.Equal(t, 2, retryErr.Attempts)
+	})
+
+	t.Run("should return typed error with invalid service name", func(t *testing.T) {
+		s := RunNatsServerOnPort(t, -1)
+		defer s.Shutdown()
+
+		config := &Config{
+			NatsUrl:            s.ClientURL(),
+			NatsServiceName:    "invalid service name",
+			NatsServiceVersion: "1.0.0",
+			Timeout:            2 * time.Second,
+		}
+
+		client, err := ConnectNatsClient(context.Background(), config)
+		assert.Nil(t, client)
+		assert.ErrorIs(t, err, ErrAddService)
+	})
+
+	t.Run("should connect successfully with valid config", func(t *testing.T) {
+		s := RunNatsServerOnPort(t, -1)
+		defer s.Shutdown()
+
+		config := &Config{
+			NatsUrl:            s.ClientURL(),
+			NatsServiceName:    "test-service",
+			NatsServiceVersion: "1.0.0",
+			Timeout:            2 * time.Second,
+		}
+
+		client, err := ConnectNatsClient(context.Background(), config)
+		assert.NoError(t, err)
+		assert.NotNil(t, client.GetInstance().Conn)
+		assert.NotNil(t, client.GetInstance().Service)
+
+		client.Disconnect()
+	})
+}
@@ -1,18 +1,31 @@
 package micro
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/afteracademy/goserve/v2/tracing"
 	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func RequestNats[S any, R any](client NatsClient, subject string, sData *S) (*R, error) {
+	return RequestNatsWithContext[S, R](context.Background(), client, subject, sData)
+}
+
+// RequestNatsWithContext forwards the request id found in ctx as a NATS header.
+func RequestNatsWithContext[S any, R any](ctx context.Context, client NatsClient, subject string, sData *S) (*R, error) {
 	msgJson, err := MsgToJson(sData)
 	if err != nil {
 		return nil, err
 	}
 
-	natsMsg, err := client.GetInstance().Conn.Request(subject, msgJson, client.GetInstance().Timeout)
+	natsMsg, err := request(ctx, client, subject, msgJson)
 	if err != nil {
 		return nil, err
 	}
@@ -26,12 +39,16 @@ func RequestNats[S any, R any](client NatsClient, subject string, sData *S) (*R,
 }
 
 func RequestNatsRaw[S any, R any](client NatsClient, subject string, sData *S) (*R, *nats.Msg, error) {
+	return RequestNatsRawWithContext[S, R](context.Background(), client, subject, sData)
+}
+
+func RequestNatsRawWithContext[S any, R any](ctx context.Context, client NatsClient, subject string, sData *S) (*R, *nats.Msg, error) {
 	jData, err := json.Marshal(sData)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	natsMsg, err := client.GetInstance().Conn.Request(subject, jData, client.GetInstance().Timeout)
+	natsMsg, err := request(ctx, client, subject, jData)
 	if err != nil {
 		return nil, natsMsg, err
 	}
@@ -44,3 +61,47 @@ func RequestNatsRaw[S any, R any](client NatsClient, subject string, sData *S) (
 
 	return &rData, natsMsg, err
 }
+
+func request(ctx context.Context, client NatsClient, subject string, data []byte) (res *nats.Msg, err error) {
+	instance := client.GetInstance()
+
+	ctx, span := instance.tracer().Start(ctx, "nats request "+subject,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "nats"),
+			attribute.String("messaging.destination.name", subject),
+		),
+	)
+	start := time.Now()
+	defer func() {
+		instance.metrics.ObserveNatsRequest(subject, time.Since(start), err, errors.Is(err, nats.ErrTimeout))
+		tracing.End(span, err)
+	}()
+
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	if id := network.RequestIdFromContext(ctx); id != "" {
+		msg.Header.Set(network.RequestIdHeader, id)
+	}
+	tracing.Inject(ctx, msg.Header)
+
+	ctx, cancel := context.WithTimeout(ctx, instance.Timeout)
+	defer cancel()
+
+	res, err = instance.Conn.RequestMsgWithContext(ctx, msg)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return res, fmt.Errorf("%w: %w", nats.ErrTimeout, err)
+	}
+	return res, err
+}
+
+// RequestId returns the request id forwarded by the caller, if any.
+func RequestId(req NatsRequest) string {
+	return req.Headers().Get(network.RequestIdHeader)
+}
+
+// RequestContext returns a context carrying the caller's request id and trace
+// so it can be passed on to downstream RequestNatsWithContext calls.
+func RequestContext(req NatsRequest) context.Context {
+	return requestContext(req)
+}
@@ -1,12 +1,15 @@
 package micro
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/afteracademy/goserve/v2/network"
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -305,3 +308,54 @@ func TestRequestNatsRaw(t *testing.T) {
 		assert.Equal(t, 42, *resp)
 	})
 }
+
+func TestRequestNatsWithContext_RequestId(t *testing.T) {
+	s := RunNatsServerOnPort(t, -1)
+	defer s.Shutdown()
+
+	nc, err := nats.Connect(s.ClientURL())
+	assert.NoError(t, err)
+	defer nc.Close()
+
+	service, err := micro.AddService(nc, micro.Config{
+		Name:    "test-service",
+		Version: "1.0.0",
+	})
+	assert.NoError(t, err)
+
+	mockNatsClient := &natsClient{
+		Conn:    nc,
+		Service: service,
+		Timeout: 2 * time.Second,
+	}
+
+	type TestRequest struct {
+		Action string `json:"action" validate:"required"`
+	}
+
+	type TestResponse struct {
+		RequestId string `json:"requestId" validate:"required"`
+	}
+
+	var forwarded context.Context
+	err = service.AddEndpoint("echo", micro.HandlerFunc(func(req micro.Request) {
+		forwarded = RequestContext(req)
+		RespondNatsMessage(req, &TestResponse{RequestId: RequestId(req)})
+	}), micro.WithEndpointSubject("test.echo"))
+	assert.NoError(t, err)
+
+	t.Run("should forward request id as header", func(t *testing.T) {
+		ctx := network.ContextWithRequestId(context.Background(), "req-123")
+		resp, err := RequestNatsWithContext[TestRequest, TestResponse](ctx, mockNatsClient, "test.echo", &TestRequest{Action: "echo"})
+		assert.NoError(t, err)
+		assert.Equal(t, "req-123", resp.RequestId)
+		assert.Equal(t, "req-123", network.RequestIdFromContext(forwarded))
+	})
+
+	t.Run("should not send header without request id", func(t *testing.T) {
+		_, natsMsg, err := RequestNatsRawWithContext[TestRequest, message[TestResponse]](context.Background(), mockNatsClient, "test.echo", &TestRequest{Action: "echo"})
+		assert.NoError(t, err)
+		assert.NotNil(t, natsMsg)
+		assert.Equal(t, "", network.RequestIdFromContext(forwarded))
+	})
+}
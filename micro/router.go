@@ -1,6 +1,7 @@
 package micro
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -15,8 +16,12 @@ type router struct {
 }
 
 func NewRouter(mode string, natsClient NatsClient) Router {
+	return NewRouterWithConfig(network.RouterConfig{Mode: mode}, natsClient)
+}
+
+func NewRouterWithConfig(config network.RouterConfig, natsClient NatsClient) Router {
 	return &router{
-		netRouter:  network.NewRouter(mode),
+		netRouter:  network.NewRouterWithConfig(config),
 		natsClient: natsClient,
 	}
 }
@@ -50,14 +55,45 @@ func (r *router) LoadControllers(controllers []Controller) {
 		}
 
 		ng := natsClient.Service.AddGroup(baseSub)
+		if natsClient.tracerProvider != nil {
+			ng = newTracedGroup(ng, natsClient.tracer())
+		}
 		c.MountNats(ng)
 	}
 }
 
+func (r *router) OnStart(hooks ...network.LifecycleHook) {
+	r.netRouter.OnStart(hooks...)
+}
+
+func (r *router) OnShutdown(hooks ...network.LifecycleHook) {
+	r.netRouter.OnShutdown(hooks...)
+}
+
 func (r *router) Start(ip string, port uint16) {
 	r.netRouter.Start(ip, port)
 }
 
+func (r *router) StartGracefully(ip string, port uint16) error {
+	return r.netRouter.StartGracefully(ip, port)
+}
+
+func (r *router) Serve(ctx context.Context, ip string, port uint16) error {
+	return r.netRouter.Serve(ctx, ip, port)
+}
+
 func (r *router) RegisterValidationParsers(tagNameFunc validator.TagNameFunc) {
 	r.netRouter.RegisterValidationParsers(tagNameFunc)
 }
+
+func (r *router) RegisterValidation(tag string, fn validator.Func) error {
+	return r.netRouter.RegisterValidation(tag, fn)
+}
+
+func (r *router) RegisterStructValidation(fn validator.StructLevelFunc, types ...any) {
+	r.netRouter.RegisterStructValidation(fn, types...)
+}
+
+func (r *router) RegisterAlias(alias string, tags string) {
+	r.netRouter.RegisterAlias(alias, tags)
+}
@@ -1,6 +1,7 @@
 package micro
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -307,3 +308,46 @@ func TestRouter_LoadControllers(t *testing.T) {
 		mockController.AssertExpectations(t)
 	})
 }
+
+func TestRouter_Serve(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should run lifecycle hooks and drain nats on shutdown", func(t *testing.T) {
+		s := RunNatsServerOnPort(t, -1)
+		defer s.Shutdown()
+
+		nc, err := nats.Connect(s.ClientURL())
+		assert.NoError(t, err)
+		defer nc.Close()
+
+		service, err := micro.AddService(nc, micro.Config{
+			Name:    "test-service",
+			Version: "1.0.0",
+		})
+		assert.NoError(t, err)
+
+		mockNatsClient := &natsClient{
+			Conn:    nc,
+			Service: service,
+			Timeout: 2 * time.Second,
+		}
+
+		router := NewRouter(gin.TestMode, mockNatsClient)
+
+		started := false
+		router.OnStart(func(ctx context.Context) error {
+			started = true
+			return nil
+		})
+		router.OnShutdown(mockNatsClient.Drain)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err = router.Serve(ctx, "127.0.0.1", 0)
+		assert.NoError(t, err)
+		assert.True(t, started)
+		assert.True(t, service.Stopped())
+		assert.True(t, nc.IsClosed())
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/afteracademy/goserve/v2/network"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func RespondNatsMessage[T any](req NatsRequest, data *T) {
@@ -17,6 +19,12 @@ func RespondNatsMessage[T any](req NatsRequest, data *T) {
 }
 
 func RespondNatsError(req NatsRequest, err error) {
+	if r, ok := req.(*tracedRequest); ok {
+		span := trace.SpanFromContext(r.Context())
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
 	if apiError, ok := err.(network.ApiError); ok {
 		msg := fmt.Sprintf("%d:%s", apiError.GetCode(), apiError.GetMessage())
 		req.RespondJSON(NewMessage[any](nil, errors.New(msg)))
@@ -0,0 +1,72 @@
+package micro
+
+import (
+	"context"
+
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/afteracademy/goserve/v2/tracing"
+	"github.com/nats-io/nats.go/micro"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedGroup starts a server span around every endpoint handler,
+// continuing the trace propagated in the request headers.
+type tracedGroup struct {
+	NatsGroup
+	tracer trace.Tracer
+}
+
+func newTracedGroup(group NatsGroup, tracer trace.Tracer) NatsGroup {
+	return &tracedGroup{
+		NatsGroup: group,
+		tracer:    tracer,
+	}
+}
+
+func (g *tracedGroup) AddGroup(name string, opts ...micro.GroupOpt) micro.Group {
+	return newTracedGroup(g.NatsGroup.AddGroup(name, opts...), g.tracer)
+}
+
+func (g *tracedGroup) AddEndpoint(name string, handler micro.Handler, opts ...micro.EndpointOpt) error {
+	return g.NatsGroup.AddEndpoint(name, g.wrap(handler), opts...)
+}
+
+func (g *tracedGroup) wrap(handler micro.Handler) micro.Handler {
+	return micro.HandlerFunc(func(req micro.Request) {
+		ctx, span := g.tracer.Start(
+			requestContext(req),
+			"nats receive "+req.Subject(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("messaging.system", "nats"),
+				attribute.String("messaging.destination.name", req.Subject()),
+			),
+		)
+		defer span.End()
+
+		handler.Handle(&tracedRequest{NatsRequest: req, ctx: ctx})
+	})
+}
+
+type tracedRequest struct {
+	NatsRequest
+	ctx context.Context
+}
+
+func (r *tracedRequest) Context() context.Context {
+	return r.ctx
+}
+
+func requestContext(req NatsRequest) context.Context {
+	if r, ok := req.(interface{ Context() context.Context }); ok {
+		return r.Context()
+	}
+
+	headers := req.Headers()
+	ctx := tracing.Extract(context.Background(), headers)
+	if id := headers.Get(network.RequestIdHeader); id != "" {
+		ctx = network.ContextWithRequestId(ctx, id)
+	}
+	return ctx
+}
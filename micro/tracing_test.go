@@ -0,0 +1,109 @@
+package micro
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing_RequestNats(t *testing.T) {
+	s := RunNatsServerOnPort(t, -1)
+	defer s.Shutdown()
+
+	nc, err := nats.Connect(s.ClientURL())
+	assert.NoError(t, err)
+	defer nc.Close()
+
+	service, err := micro.AddService(nc, micro.Config{
+		Name:    "test-service",
+		Version: "1.0.0",
+	})
+	assert.NoError(t, err)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	client := &natsClient{
+		Conn:           nc,
+		Service:        service,
+		Timeout:        2 * time.Second,
+		tracerProvider: tp,
+	}
+
+	type TestRequest struct {
+		Action string `json:"action" validate:"required"`
+	}
+
+	type TestResponse struct {
+		Status string `json:"status" validate:"required"`
+	}
+
+	group := newTracedGroup(service.AddGroup("test"), client.tracer())
+	var handlerSpan trace.SpanContext
+	err = group.AddEndpoint("ok", micro.HandlerFunc(func(req micro.Request) {
+		handlerSpan = trace.SpanContextFromContext(RequestContext(req))
+		RespondNatsMessage(req, &TestResponse{Status: "ok"})
+	}))
+	assert.NoError(t, err)
+	err = group.AddEndpoint("fail", micro.HandlerFunc(func(req micro.Request) {
+		RespondNatsError(req, errors.New("failed"))
+	}))
+	assert.NoError(t, err)
+
+	t.Run("should link client and server spans", func(t *testing.T) {
+		exporter.Reset()
+
+		_, err := RequestNatsWithContext[TestRequest, TestResponse](context.Background(), client, "test.ok", &TestRequest{Action: "ok"})
+		assert.NoError(t, err)
+
+		assert.Eventually(t, func() bool { return len(exporter.GetSpans()) == 2 }, time.Second, 10*time.Millisecond)
+		server := spanOfKind(exporter.GetSpans(), trace.SpanKindServer)
+		clientSpan := spanOfKind(exporter.GetSpans(), trace.SpanKindClient)
+		assert.Equal(t, trace.SpanKindServer, server.SpanKind)
+		assert.Equal(t, trace.SpanKindClient, clientSpan.SpanKind)
+		assert.Equal(t, "nats request test.ok", clientSpan.Name)
+		assert.Equal(t, clientSpan.SpanContext.TraceID(), server.SpanContext.TraceID())
+		assert.Equal(t, clientSpan.SpanContext.SpanID(), server.Parent.SpanID())
+		assert.Equal(t, server.SpanContext.SpanID(), handlerSpan.SpanID())
+	})
+
+	t.Run("should mark server span as error", func(t *testing.T) {
+		exporter.Reset()
+
+		_, err := RequestNatsWithContext[TestRequest, TestResponse](context.Background(), client, "test.fail", &TestRequest{Action: "fail"})
+		assert.Error(t, err)
+
+		assert.Eventually(t, func() bool { return len(exporter.GetSpans()) == 2 }, time.Second, 10*time.Millisecond)
+		server := spanOfKind(exporter.GetSpans(), trace.SpanKindServer)
+		assert.Equal(t, codes.Error, server.Status.Code)
+	})
+
+	t.Run("should mark client span as error on timeout", func(t *testing.T) {
+		exporter.Reset()
+
+		_, err := RequestNatsWithContext[TestRequest, TestResponse](context.Background(), client, "test.missing", &TestRequest{Action: "missing"})
+		assert.ErrorIs(t, err, nats.ErrNoResponders)
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+	})
+}
+
+func spanOfKind(spans tracetest.SpanStubs, kind trace.SpanKind) tracetest.SpanStub {
+	for _, s := range spans {
+		if s.SpanKind == kind {
+			return s
+		}
+	}
+	return tracetest.SpanStub{}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"errors"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/metrics"
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+)
+
+const unmatchedRoute = "unmatched"
+
+type metricsRecorder struct {
+	metrics *metrics.Metrics
+}
+
+func NewMetrics(m *metrics.Metrics) network.RootMiddleware {
+	return &metricsRecorder{
+		metrics: m,
+	}
+}
+
+func (m *metricsRecorder) Attach(engine *gin.Engine) {
+	engine.Use(m.Handler)
+}
+
+func (m *metricsRecorder) Handler(ctx *gin.Context) {
+	start := time.Now()
+
+	ctx.Next()
+
+	// label by route template and not the raw url to keep cardinality bounded
+	route := ctx.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+	m.metrics.ObserveRequest(route, ctx.Request.Method, ctx.Writer.Status(), time.Since(start))
+
+	for _, e := range ctx.Errors {
+		var apiError network.ApiError
+		if errors.As(e.Err, &apiError) {
+			m.metrics.ObserveApiError(apiError.GetCode())
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/afteracademy/goserve/v2/metrics"
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func scrape(m *metrics.Metrics) string {
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return rr.Body.String()
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	m := metrics.New(metrics.Config{Namespace: "test", Registry: prometheus.NewRegistry()})
+
+	mockHandler := func(ctx *gin.Context) {
+		network.SendSuccessMsgResponse(ctx, "success")
+	}
+
+	rr := network.MockTestRootMiddlewareWithUrl(t, "/blogs/:id", "/blogs/42", NewMetrics(m), mockHandler, nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	body := scrape(m)
+	assert.Contains(t, body, `test_http_requests_total{method="GET",route="/blogs/:id",status="200"} 1`)
+	assert.Contains(t, body, `test_http_request_duration_seconds_count{method="GET",route="/blogs/:id",status="200"} 1`)
+	assert.NotContains(t, body, "/blogs/42")
+}
+
+func TestMetricsMiddleware_ApiError(t *testing.T) {
+	m := metrics.New(metrics.Config{Namespace: "test", Registry: prometheus.NewRegistry()})
+
+	mockHandler := func(ctx *gin.Context) {
+		network.SendForbiddenError(ctx, "denied", nil)
+	}
+
+	rr := network.MockTestRootMiddleware(t, NewMetrics(m), mockHandler, nil)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	body := scrape(m)
+	assert.Contains(t, body, `test_api_errors_total{code="403"} 1`)
+	assert.Contains(t, body, `test_http_requests_total{method="GET",route="/",status="403"} 1`)
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const maxRequestIdLength = 128
+
+type requestId struct {
+	generate func() string
+}
+
+func NewRequestId() network.RootMiddleware {
+	return NewRequestIdWithGenerator(uuid.NewString)
+}
+
+func NewRequestIdWithGenerator(generate func() string) network.RootMiddleware {
+	return &requestId{
+		generate: generate,
+	}
+}
+
+func (m *requestId) Attach(engine *gin.Engine) {
+	engine.Use(m.Handler)
+}
+
+func (m *requestId) Handler(ctx *gin.Context) {
+	id := ctx.GetHeader(network.RequestIdHeader)
+	if !validRequestId(id) {
+		id = m.generate()
+	}
+	network.SetRequestId(ctx, id)
+	ctx.Next()
+}
+
+// only accept printable ascii ids from clients so they are safe to log and echo
+func validRequestId(id string) bool {
+	if len(id) == 0 || len(id) > maxRequestIdLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
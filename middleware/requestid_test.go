@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIdMiddleware_Generates(t *testing.T) {
+	var fromGin, fromContext string
+	mockHandler := func(ctx *gin.Context) {
+		fromGin = network.RequestId(ctx)
+		fromContext = network.RequestIdFromContext(ctx.Request.Context())
+		network.SendSuccessMsgResponse(ctx, "success")
+	}
+
+	middleware := NewRequestIdWithGenerator(func() string { return "generated-id" })
+	rr := network.MockTestRootMiddleware(t, middleware, mockHandler, nil)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "generated-id", rr.Header().Get(network.RequestIdHeader))
+	assert.Equal(t, "generated-id", fromGin)
+	assert.Equal(t, "generated-id", fromContext)
+}
+
+func TestRequestIdMiddleware_ReusesIncoming(t *testing.T) {
+	var fromGin string
+	mockHandler := func(ctx *gin.Context) {
+		fromGin = network.RequestId(ctx)
+		network.SendSuccessMsgResponse(ctx, "success")
+	}
+
+	rr := network.MockTestRootMiddleware(t, NewRequestId(), mockHandler, map[string]string{
+		network.RequestIdHeader: "incoming-id",
+	})
+
+	assert.Equal(t, "incoming-id", rr.Header().Get(network.RequestIdHeader))
+	assert.Equal(t, "incoming-id", fromGin)
+}
+
+func TestRequestIdMiddleware_RejectsInvalidIncoming(t *testing.T) {
+	mockHandler := func(ctx *gin.Context) {
+		network.SendSuccessMsgResponse(ctx, "success")
+	}
+
+	rr := network.MockTestRootMiddleware(t, NewRequestId(), mockHandler, map[string]string{
+		network.RequestIdHeader: strings.Repeat("a", maxRequestIdLength+1),
+	})
+
+	id := rr.Header().Get(network.RequestIdHeader)
+	assert.Len(t, id, 36)
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+)
+
+type requestLogger struct {
+	logger logger.Logger
+}
+
+func NewRequestLogger(log logger.Logger) network.RootMiddleware {
+	return &requestLogger{
+		logger: logger.Or(log),
+	}
+}
+
+func (m *requestLogger) Attach(engine *gin.Engine) {
+	engine.Use(m.Handler)
+}
+
+func (m *requestLogger) Handler(ctx *gin.Context) {
+	start := time.Now()
+	path := ctx.Request.URL.Path
+
+	ctx.Next()
+
+	status := ctx.Writer.Status()
+	args := []any{
+		"method", ctx.Request.Method,
+		"path", path,
+		"route", ctx.FullPath(),
+		"status", status,
+		"latency", time.Since(start),
+		"client_ip", ctx.ClientIP(),
+		"request_id", network.RequestId(ctx),
+	}
+	if len(ctx.Errors) > 0 {
+		args = append(args, "errors", ctx.Errors.String())
+	}
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		m.logger.Error("request", args...)
+	case status >= http.StatusBadRequest:
+		m.logger.Warn("request", args...)
+	default:
+		m.logger.Info("request", args...)
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestLoggerMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewJSON(&buf, slog.LevelInfo)
+
+	mockHandler := func(ctx *gin.Context) {
+		network.SetRequestId(ctx, "req-123")
+		network.SendSuccessMsgResponse(ctx, "success")
+	}
+
+	rr := network.MockTestRootMiddleware(t, NewRequestLogger(log), mockHandler, nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var entry map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "INFO", entry["level"])
+	assert.Equal(t, "request", entry["msg"])
+	assert.Equal(t, "GET", entry["method"])
+	assert.Equal(t, "/", entry["path"])
+	assert.Equal(t, float64(http.StatusOK), entry["status"])
+	assert.Equal(t, "req-123", entry["request_id"])
+	assert.Contains(t, entry, "latency")
+}
+
+func TestRequestLoggerMiddleware_ErrorLevel(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewJSON(&buf, slog.LevelInfo)
+
+	mockHandler := func(ctx *gin.Context) {
+		network.SendNotFoundError(ctx, "missing", nil)
+	}
+
+	rr := network.MockTestRootMiddlewareWithUrl(t, "/blogs/:id", "/blogs/42", NewRequestLogger(log), mockHandler, nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	var entry map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "/blogs/42", entry["path"])
+	assert.Equal(t, "/blogs/:id", entry["route"])
+}
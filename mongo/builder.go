@@ -4,7 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/afteracademy/goserve/v2/logger"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type QueryBuilder[T any] interface {
@@ -16,6 +18,8 @@ type QueryBuilder[T any] interface {
 type queryBuilder[T any] struct {
 	collection *mongo.Collection
 	timeout    time.Duration
+	logger     logger.Logger
+	tracer     trace.Tracer
 }
 
 func (c *queryBuilder[T]) GetCollection() *mongo.Collection {
@@ -23,16 +27,18 @@ func (c *queryBuilder[T]) GetCollection() *mongo.Collection {
 }
 
 func (c *queryBuilder[T]) SingleQuery() Query[T] {
-	return newSingleQuery[T](c.collection, c.timeout)
+	return newSingleQuery[T](c.collection, c.timeout, c.logger, c.tracer)
 }
 
 func (c *queryBuilder[T]) Query(context context.Context) Query[T] {
-	return newQuery[T](context, c.collection)
+	return newQuery[T](context, c.collection, c.logger, c.tracer)
 }
 
 func NewQueryBuilder[T any](db Database, collectionName string) QueryBuilder[T] {
 	return &queryBuilder[T]{
 		collection: db.GetInstance().Collection(collectionName),
 		timeout:    db.GetInstance().config.Timeout,
+		logger:     db.GetInstance().logger,
+		tracer:     db.GetInstance().tracer,
 	}
 }
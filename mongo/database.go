@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"os"
 	"time"
 
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/metrics"
+	"github.com/afteracademy/goserve/v2/tracing"
+	"github.com/afteracademy/goserve/v2/utility"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type DbConfig struct {
@@ -21,8 +26,19 @@ type DbConfig struct {
 	MinPoolSize uint16
 	MaxPoolSize uint16
 	Timeout     time.Duration
+	Retry       utility.RetryConfig
+	Logger      logger.Logger
+	// TracerProvider enables spans for Query operations when set.
+	TracerProvider trace.TracerProvider
+	// Metrics exposes the connection pool stats when set.
+	Metrics *metrics.Metrics
 }
 
+var (
+	ErrConnect = errors.New("connection to mongo failed")
+	ErrPing    = errors.New("pinging to mongo failed")
+)
+
 type Document[T any] interface {
 	EnsureIndexes(Database)
 }
@@ -30,19 +46,25 @@ type Document[T any] interface {
 type Database interface {
 	GetInstance() *database
 	Connect()
+	ConnectContext(ctx context.Context) error
 	Disconnect()
+	DisconnectContext(ctx context.Context) error
 }
 
 type database struct {
 	*mongo.Database
 	context context.Context
 	config  DbConfig
+	logger  logger.Logger
+	tracer  trace.Tracer
 }
 
 func NewDatabase(ctx context.Context, config DbConfig) Database {
 	db := database{
 		context: ctx,
 		config:  config,
+		logger:  logger.Or(config.Logger),
+		tracer:  tracing.Tracer(config.TracerProvider),
 	}
 	return &db
 }
@@ -52,6 +74,13 @@ func (db *database) GetInstance() *database {
 }
 
 func (db *database) Connect() {
+	if err := db.ConnectContext(db.context); err != nil {
+		db.logger.Error("connection to mongo failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func (db *database) ConnectContext(ctx context.Context) error {
 	uri := fmt.Sprintf(
 		"mongodb://%s:%s@%s:%d/%s",
 		db.config.User, db.config.Pwd, db.config.Host, db.config.Port, db.config.Name,
@@ -59,30 +88,74 @@ func (db *database) Connect() {
 
 	clientOptions := options.Client().ApplyURI(uri)
 	clientOptions.SetMaxPoolSize(uint64(db.config.MaxPoolSize))
-	clientOptions.SetMaxPoolSize(uint64(db.config.MinPoolSize))
+	clientOptions.SetMinPoolSize(uint64(db.config.MinPoolSize))
 
-	fmt.Println("connecting mongo...")
-	client, err := mongo.Connect(db.context, clientOptions)
-	if err != nil {
-		log.Fatal("connection to mongo failed!: ", err)
+	var stats *poolStats
+	if db.config.Metrics != nil {
+		stats = &poolStats{max: int64(db.config.MaxPoolSize)}
+		clientOptions.SetPoolMonitor(stats.monitor())
 	}
 
-	err = client.Ping(db.context, nil)
+	db.logger.Info("connecting mongo", "host", db.config.Host, "port", db.config.Port, "database", db.config.Name)
+	var client *mongo.Client
+	err := utility.Retry(ctx, db.config.Retry, func(ctx context.Context) error {
+		c, err := mongo.Connect(ctx, clientOptions)
+		if err != nil {
+			db.logger.Warn("mongo connection attempt failed", "error", err)
+			return fmt.Errorf("%w: %w", ErrConnect, err)
+		}
+
+		pingCtx, cancel := db.withTimeout(ctx)
+		defer cancel()
+
+		if err := c.Ping(pingCtx, nil); err != nil {
+			c.Disconnect(ctx)
+			db.logger.Warn("mongo ping attempt failed", "error", err)
+			return fmt.Errorf("%w: %w", ErrPing, err)
+		}
+
+		client = c
+		return nil
+	})
 	if err != nil {
-		log.Panic("pinging to mongo failed!: ", err)
+		return err
+	}
+	db.logger.Info("connected to mongo", "database", db.config.Name)
+
+	if stats != nil {
+		if err := db.config.Metrics.RegisterPool("mongodb", stats.snapshot); err != nil {
+			db.logger.Warn("registering mongo pool metrics failed", "error", err)
+		}
 	}
-	fmt.Println("connected to mongo!")
 
 	db.Database = client.Database(db.config.Name)
+	return nil
 }
 
 func (db *database) Disconnect() {
-	fmt.Println("disconnecting mongo...")
-	err := db.Client().Disconnect(db.context)
-	if err != nil {
-		log.Panic(err)
+	if err := db.DisconnectContext(db.context); err != nil {
+		db.logger.Error("disconnecting mongo failed", "error", err)
+		panic(err)
+	}
+}
+
+func (db *database) DisconnectContext(ctx context.Context) error {
+	db.logger.Info("disconnecting mongo")
+	if db.Database == nil {
+		return nil
+	}
+	if err := db.Client().Disconnect(ctx); err != nil {
+		return err
+	}
+	db.logger.Info("disconnected mongo")
+	return nil
+}
+
+func (db *database) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if db.config.Timeout <= 0 {
+		return context.WithCancel(ctx)
 	}
-	fmt.Println("disconnected mongo")
+	return context.WithTimeout(ctx, db.config.Timeout)
 }
 
 func NewObjectID(id string) (primitive.ObjectID, error) {
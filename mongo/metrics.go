@@ -0,0 +1,44 @@
+package mongo
+
+import (
+	"sync/atomic"
+
+	"github.com/afteracademy/goserve/v2/metrics"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// poolStats tracks the connection pool from driver events since the mongo
+// driver does not expose pool counters directly.
+type poolStats struct {
+	open  atomic.Int64
+	inUse atomic.Int64
+	max   int64
+}
+
+func (p *poolStats) monitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				p.open.Add(1)
+			case event.ConnectionClosed:
+				p.open.Add(-1)
+			case event.GetSucceeded:
+				p.inUse.Add(1)
+			case event.ConnectionReturned:
+				p.inUse.Add(-1)
+			}
+		},
+	}
+}
+
+func (p *poolStats) snapshot() metrics.PoolStats {
+	open := p.open.Load()
+	inUse := p.inUse.Load()
+	return metrics.PoolStats{
+		Open:  open,
+		InUse: inUse,
+		Idle:  max(open-inUse, 0),
+		Max:   p.max,
+	}
+}
@@ -0,0 +1,31 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+func TestPoolStats(t *testing.T) {
+	stats := &poolStats{max: 10}
+	monitor := stats.monitor()
+
+	for _, e := range []string{
+		event.ConnectionCreated,
+		event.ConnectionCreated,
+		event.ConnectionCreated,
+		event.GetSucceeded,
+		event.GetSucceeded,
+		event.ConnectionReturned,
+		event.ConnectionClosed,
+	} {
+		monitor.Event(&event.PoolEvent{Type: e})
+	}
+
+	snapshot := stats.snapshot()
+	assert.Equal(t, int64(2), snapshot.Open)
+	assert.Equal(t, int64(1), snapshot.InUse)
+	assert.Equal(t, int64(1), snapshot.Idle)
+	assert.Equal(t, int64(10), snapshot.Max)
+}
@@ -2,13 +2,21 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"math"
+	"strings"
 	"time"
 
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/tracing"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Query[T any] interface {
@@ -17,6 +25,9 @@ type Query[T any] interface {
 	FindOne(filter bson.M, opts *options.FindOneOptions) (*T, error)
 	FindAll(filter bson.M, opts *options.FindOptions) ([]*T, error)
 	FindPaginated(filter bson.M, page int64, limit int64, opts *options.FindOptions) ([]*T, error)
+	FindPaginatedWithCount(filter bson.M, page int64, limit int64, opts *options.FindOptions) ([]*T, int64, error)
+	FindAfter(filter bson.M, sortKey string, order int, after []byte, limit int64, opts *options.FindOptions) ([]*T, []byte, error)
+	Stream(ctx context.Context, filter bson.M, opts *options.FindOptions) iter.Seq2[*T, error]
 	InsertOne(doc *T) (*primitive.ObjectID, error)
 	InsertAndRetrieveOne(doc *T) (*T, error)
 	InsertMany(doc []*T) ([]primitive.ObjectID, error)
@@ -30,21 +41,27 @@ type query[T any] struct {
 	collection *mongo.Collection
 	context    context.Context
 	cancel     context.CancelFunc
+	logger     logger.Logger
+	tracer     trace.Tracer
 }
 
-func newSingleQuery[T any](collection *mongo.Collection, timeout time.Duration) Query[T] {
+func newSingleQuery[T any](collection *mongo.Collection, timeout time.Duration, log logger.Logger, tracer trace.Tracer) Query[T] {
 	context, cancel := context.WithTimeout(context.Background(), timeout)
 	return &query[T]{
 		context:    context,
 		cancel:     cancel,
 		collection: collection,
+		logger:     logger.Or(log),
+		tracer:     tracer,
 	}
 }
 
-func newQuery[T any](context context.Context, collection *mongo.Collection) Query[T] {
+func newQuery[T any](context context.Context, collection *mongo.Collection, log logger.Logger, tracer trace.Tracer) Query[T] {
 	return &query[T]{
 		context:    context,
 		collection: collection,
+		logger:     logger.Or(log),
+		tracer:     tracer,
 	}
 }
 
@@ -54,18 +71,52 @@ func (q *query[T]) Close() {
 	}
 }
 
-func (q *query[T]) CreateIndexes(indexes []mongo.IndexModel) error {
+func (q *query[T]) startSpan(operation string) (context.Context, trace.Span) {
+	return q.startSpanWith(q.context, operation)
+}
+
+func (q *query[T]) startSpanWith(ctx context.Context, operation string) (context.Context, trace.Span) {
+	if q.tracer == nil {
+		q.tracer = tracing.Tracer(nil)
+	}
+	return q.tracer.Start(ctx, operation+" "+q.collection.Name(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "mongodb"),
+			attribute.String("db.collection.name", q.collection.Name()),
+			attribute.String("db.operation.name", operation),
+		),
+	)
+}
+
+// endSpan does not flag a missing document as a span error, it is an expected outcome
+func endSpan(span trace.Span, err error) {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = nil
+	}
+	tracing.End(span, err)
+}
+
+func (q *query[T]) CreateIndexes(indexes []mongo.IndexModel) (err error) {
 	defer q.Close()
-	fmt.Println("database indexing for: " + q.collection.Name())
-	result, err := q.collection.Indexes().CreateMany(q.context, indexes)
-	fmt.Println(q.collection.Name(), result)
-	return err
+	ctx, span := q.startSpan("createIndexes")
+	defer func() { endSpan(span, err) }()
+	q.logger.Info("database indexing", "collection", q.collection.Name())
+	result, err := q.collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		q.logger.Error("database indexing failed", "collection", q.collection.Name(), "error", err)
+		return err
+	}
+	q.logger.Info("database indexed", "collection", q.collection.Name(), "indexes", result)
+	return nil
 }
 
-func (q *query[T]) FindOne(filter bson.M, opts *options.FindOneOptions) (*T, error) {
+func (q *query[T]) FindOne(filter bson.M, opts *options.FindOneOptions) (_ *T, err error) {
 	defer q.Close()
+	ctx, span := q.startSpan("findOne")
+	defer func() { endSpan(span, err) }()
 	var doc T
-	err := q.collection.FindOne(q.context, filter, opts).Decode(&doc)
+	err = q.collection.FindOne(ctx, filter, opts).Decode(&doc)
 	if err != nil {
 		return nil, err
 	}
@@ -73,17 +124,19 @@ func (q *query[T]) FindOne(filter bson.M, opts *options.FindOneOptions) (*T, err
 	return &doc, nil
 }
 
-func (q *query[T]) FindAll(filter bson.M, opts *options.FindOptions) ([]*T, error) {
+func (q *query[T]) FindAll(filter bson.M, opts *options.FindOptions) (_ []*T, err error) {
 	defer q.Close()
-	cursor, err := q.collection.Find(q.context, filter, opts)
+	ctx, span := q.startSpan("find")
+	defer func() { endSpan(span, err) }()
+	cursor, err := q.collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error executing query: %w", err)
 	}
-	defer cursor.Close(q.context)
+	defer cursor.Close(ctx)
 
 	var docs []*T
 
-	for cursor.Next(q.context) {
+	for cursor.Next(ctx) {
 		var result T
 		err := cursor.Decode(&result)
 		if err != nil {
@@ -99,25 +152,67 @@ func (q *query[T]) FindAll(filter bson.M, opts *options.FindOptions) ([]*T, erro
 	return docs, nil
 }
 
-func (q *query[T]) FindPaginated(filter bson.M, page int64, limit int64, opts *options.FindOptions) ([]*T, error) {
+// ErrInvalidPage is returned for a page or limit below 1, or a page so far
+// that its offset overflows.
+var ErrInvalidPage = errors.New("page and limit must be at least 1")
+
+func validatePage(page int64, limit int64) error {
+	if page < 1 || limit < 1 || page-1 > math.MaxInt64/limit {
+		return ErrInvalidPage
+	}
+	return nil
+}
+
+func (q *query[T]) FindPaginated(filter bson.M, page int64, limit int64, opts *options.FindOptions) (_ []*T, err error) {
+	defer q.Close()
+	if err := validatePage(page, limit); err != nil {
+		return nil, err
+	}
+	ctx, span := q.startSpan("find")
+	defer func() { endSpan(span, err) }()
+	return q.findPage(ctx, filter, page, limit, opts)
+}
+
+// FindPaginatedWithCount also returns the number of documents matching
+// filter across all pages.
+func (q *query[T]) FindPaginatedWithCount(filter bson.M, page int64, limit int64, opts *options.FindOptions) (_ []*T, _ int64, err error) {
 	defer q.Close()
+	if err := validatePage(page, limit); err != nil {
+		return nil, 0, err
+	}
+	ctx, span := q.startSpan("find")
+	defer func() { endSpan(span, err) }()
+
+	total, err := q.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error counting documents: %w", err)
+	}
+
+	docs, err := q.findPage(ctx, filter, page, limit, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return docs, total, nil
+}
+
+func (q *query[T]) findPage(ctx context.Context, filter bson.M, page int64, limit int64, opts *options.FindOptions) ([]*T, error) {
 	skip := (page - 1) * limit
 
 	if opts == nil {
 		opts = options.Find()
 	}
 	opts.SetSkip(skip)
-	opts.SetLimit(int64(limit))
+	opts.SetLimit(limit)
 
-	cursor, err := q.collection.Find(q.context, filter, opts)
+	cursor, err := q.collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error executing query: %w", err)
 	}
-	defer cursor.Close(q.context)
+	defer cursor.Close(ctx)
 
 	var docs []*T
 
-	for cursor.Next(q.context) {
+	for cursor.Next(ctx) {
 		var result T
 		err := cursor.Decode(&result)
 		if err != nil {
@@ -133,9 +228,158 @@ func (q *query[T]) FindPaginated(filter bson.M, page int64, limit int64, opts *o
 	return docs, nil
 }
 
-func (q *query[T]) InsertOne(doc *T) (*primitive.ObjectID, error) {
+// FindAfter returns up to limit documents following the position after,
+// ordered by sortKey (1 or -1) with _id breaking ties. sortKey should be
+// indexed together with _id; an empty sortKey pages by _id alone. The
+// returned position is nil on the last page, otherwise it is passed as
+// after to get the next page.
+func (q *query[T]) FindAfter(filter bson.M, sortKey string, order int, after []byte, limit int64, opts *options.FindOptions) (_ []*T, _ []byte, err error) {
+	defer q.Close()
+	if limit < 1 {
+		return nil, nil, ErrInvalidPage
+	}
+	ctx, span := q.startSpan("find")
+	defer func() { endSpan(span, err) }()
+
+	if sortKey == "" {
+		sortKey = "_id"
+	}
+	if order >= 0 {
+		order = 1
+	} else {
+		order = -1
+	}
+
+	if after != nil {
+		var position keysetPosition
+		if err := bson.Unmarshal(after, &position); err != nil {
+			return nil, nil, fmt.Errorf("error decoding position: %w", err)
+		}
+		filter = bson.M{"$and": bson.A{filter, position.filter(sortKey, order)}}
+	}
+
+	if opts == nil {
+		opts = options.Find()
+	}
+	sort := bson.D{{Key: sortKey, Value: order}}
+	if sortKey != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: order})
+	}
+	opts.SetSort(sort)
+	// one extra document tells whether another page follows
+	opts.SetLimit(limit + 1)
+
+	cursor, err := q.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing query: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []*T
+	var last bson.Raw
+
+	for cursor.Next(ctx) {
+		if int64(len(docs)) == limit {
+			next, err := newKeysetPosition(last, sortKey)
+			if err != nil {
+				return nil, nil, err
+			}
+			return docs, next, nil
+		}
+		var result T
+		err := cursor.Decode(&result)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error decoding result: %w", err)
+		}
+		docs = append(docs, &result)
+		last = append(bson.Raw(nil), cursor.Current...)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return docs, nil, nil
+}
+
+// Stream decodes documents one at a time as the sequence is ranged over,
+// so exports never hold the whole result in memory. It runs under ctx,
+// usually the request context, instead of the query context, so a long
+// export is not cut off by the SingleQuery timeout and stops when the
+// client goes away. The query is closed right away; the cursor is opened
+// by each range over the sequence and closed when the loop ends or breaks,
+// so a sequence never ranged over holds nothing. A failure is yielded as
+// the last item.
+func (q *query[T]) Stream(ctx context.Context, filter bson.M, opts *options.FindOptions) iter.Seq2[*T, error] {
+	q.Close()
+	return func(yield func(*T, error) bool) {
+		var err error
+		ctx, span := q.startSpanWith(ctx, "find")
+		defer func() { endSpan(span, err) }()
+
+		cursor, err := q.collection.Find(ctx, filter, opts)
+		if err != nil {
+			err = fmt.Errorf("error executing query: %w", err)
+			yield(nil, err)
+			return
+		}
+		defer cursor.Close(ctx)
+
+		for cursor.Next(ctx) {
+			var doc T
+			if err = cursor.Decode(&doc); err != nil {
+				err = fmt.Errorf("error decoding result: %w", err)
+				yield(nil, err)
+				return
+			}
+			if !yield(&doc, nil) {
+				return
+			}
+		}
+
+		if err = cursor.Err(); err != nil {
+			err = fmt.Errorf("cursor error: %w", err)
+			yield(nil, err)
+		}
+	}
+}
+
+type keysetPosition struct {
+	Value bson.RawValue `bson:"v"`
+	Id    bson.RawValue `bson:"id"`
+}
+
+func newKeysetPosition(doc bson.Raw, sortKey string) ([]byte, error) {
+	id, err := doc.LookupErr("_id")
+	if err != nil {
+		return nil, fmt.Errorf("error reading _id: %w", err)
+	}
+	value, err := doc.LookupErr(strings.Split(sortKey, ".")...)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", sortKey, err)
+	}
+	return bson.Marshal(keysetPosition{Value: value, Id: id})
+}
+
+func (p *keysetPosition) filter(sortKey string, order int) bson.M {
+	op := "$gt"
+	if order < 0 {
+		op = "$lt"
+	}
+	if sortKey == "_id" {
+		return bson.M{"_id": bson.M{op: p.Id}}
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{sortKey: bson.M{op: p.Value}},
+		bson.M{sortKey: p.Value, "_id": bson.M{op: p.Id}},
+	}}
+}
+
+func (q *query[T]) InsertOne(doc *T) (_ *primitive.ObjectID, err error) {
 	defer q.Close()
-	result, err := q.collection.InsertOne(q.context, doc)
+	ctx, span := q.startSpan("insertOne")
+	defer func() { endSpan(span, err) }()
+	result, err := q.collection.InsertOne(ctx, doc)
 	if err != nil {
 		return nil, err
 	}
@@ -148,9 +392,11 @@ func (q *query[T]) InsertOne(doc *T) (*primitive.ObjectID, error) {
 	return &insertedID, nil
 }
 
-func (q *query[T]) InsertAndRetrieveOne(doc *T) (*T, error) {
+func (q *query[T]) InsertAndRetrieveOne(doc *T) (_ *T, err error) {
 	defer q.Close()
-	result, err := q.collection.InsertOne(q.context, doc)
+	ctx, span := q.startSpan("insertOne")
+	defer func() { endSpan(span, err) }()
+	result, err := q.collection.InsertOne(ctx, doc)
 	if err != nil {
 		return nil, err
 	}
@@ -164,14 +410,16 @@ func (q *query[T]) InsertAndRetrieveOne(doc *T) (*T, error) {
 	return retrieved, nil
 }
 
-func (q *query[T]) InsertMany(docs []*T) ([]primitive.ObjectID, error) {
+func (q *query[T]) InsertMany(docs []*T) (_ []primitive.ObjectID, err error) {
 	defer q.Close()
+	ctx, span := q.startSpan("insertMany")
+	defer func() { endSpan(span, err) }()
 	var iDocs []any
 	for _, doc := range docs {
 		iDocs = append(iDocs, doc)
 	}
 
-	result, err := q.collection.InsertMany(q.context, iDocs)
+	result, err := q.collection.InsertMany(ctx, iDocs)
 	if err != nil {
 		return nil, err
 	}
@@ -189,14 +437,16 @@ func (q *query[T]) InsertMany(docs []*T) ([]primitive.ObjectID, error) {
 	return insertedIDs, nil
 }
 
-func (q *query[T]) InsertAndRetrieveMany(docs []*T) ([]*T, error) {
+func (q *query[T]) InsertAndRetrieveMany(docs []*T) (_ []*T, err error) {
 	defer q.Close()
+	ctx, span := q.startSpan("insertMany")
+	defer func() { endSpan(span, err) }()
 	var iDocs []any
 	for _, doc := range docs {
 		iDocs = append(iDocs, doc)
 	}
 
-	result, err := q.collection.InsertMany(q.context, iDocs)
+	result, err := q.collection.InsertMany(ctx, iDocs)
 	if err != nil {
 		return nil, err
 	}
@@ -214,9 +464,11 @@ func (q *query[T]) InsertAndRetrieveMany(docs []*T) ([]*T, error) {
 /*
  * Example -> update := bson.M{"$set": bson.M{"field": "newValue"}}
  */
-func (q *query[T]) UpdateOne(filter bson.M, update bson.M) (*mongo.UpdateResult, error) {
+func (q *query[T]) UpdateOne(filter bson.M, update bson.M) (_ *mongo.UpdateResult, err error) {
 	defer q.Close()
-	result, err := q.collection.UpdateOne(q.context, filter, update)
+	ctx, span := q.startSpan("updateOne")
+	defer func() { endSpan(span, err) }()
+	result, err := q.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return nil, err
 	}
@@ -226,18 +478,22 @@ func (q *query[T]) UpdateOne(filter bson.M, update bson.M) (*mongo.UpdateResult,
 /*
  * Example -> update := bson.M{"$set": bson.M{"field": "newValue"}}
  */
-func (q *query[T]) UpdateMany(filter bson.M, update bson.M) (*mongo.UpdateResult, error) {
+func (q *query[T]) UpdateMany(filter bson.M, update bson.M) (_ *mongo.UpdateResult, err error) {
 	defer q.Close()
-	result, err := q.collection.UpdateMany(q.context, filter, update)
+	ctx, span := q.startSpan("updateMany")
+	defer func() { endSpan(span, err) }()
+	result, err := q.collection.UpdateMany(ctx, filter, update)
 	if err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
-func (q *query[T]) DeleteOne(filter bson.M) (*mongo.DeleteResult, error) {
+func (q *query[T]) DeleteOne(filter bson.M) (_ *mongo.DeleteResult, err error) {
 	defer q.Close()
-	result, err := q.collection.DeleteOne(q.context, filter)
+	ctx, span := q.startSpan("deleteOne")
+	defer func() { endSpan(span, err) }()
+	result, err := q.collection.DeleteOne(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,199 @@
+package mongo
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// unreachableCollection returns a collection whose operations fail fast
+// since no server is listening on the address.
+func unreachableCollection(t *testing.T) *mongo.Collection {
+	t.Helper()
+	opts := options.Client().
+		ApplyURI("mongodb://127.0.0.1:1").
+		SetServerSelectionTimeout(50 * time.Millisecond)
+	client, err := mongo.Connect(context.Background(), opts)
+	assert.NoError(t, err)
+	t.Cleanup(func() { client.Disconnect(context.Background()) })
+	return client.Database("test").Collection("blogs")
+}
+
+func TestQuery_TracingSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	parentCtx, parent := tracing.Tracer(tp).Start(context.Background(), "handler")
+	defer parent.End()
+
+	q := newQuery[bson.M](parentCtx, unreachableCollection(t), logger.Nop(), tracing.Tracer(tp))
+	_, err := q.FindOne(bson.M{}, nil)
+	assert.Error(t, err)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "findOne blogs", span.Name)
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+	assert.Equal(t, codes.Error, span.Status.Code)
+	assert.Contains(t, span.Attributes, attribute.String("db.system", "mongodb"))
+	assert.Contains(t, span.Attributes, attribute.String("db.collection.name", "blogs"))
+}
+
+func TestQuery_NoTracer(t *testing.T) {
+	q := newSingleQuery[bson.M](unreachableCollection(t), time.Second, logger.Nop(), nil)
+	_, err := q.FindAll(bson.M{}, nil)
+	assert.Error(t, err)
+}
+
+func TestQuery_FindPaginatedWithCount(t *testing.T) {
+	q := newSingleQuery[bson.M](unreachableCollection(t), time.Second, logger.Nop(), nil)
+	docs, total, err := q.FindPaginatedWithCount(bson.M{}, 1, 10, nil)
+	assert.Error(t, err)
+	assert.Nil(t, docs)
+	assert.Zero(t, total)
+}
+
+func TestQuery_FindPaginatedInvalidPage(t *testing.T) {
+	for _, c := range []struct{ page, limit int64 }{{0, 10}, {-1, 10}, {1, 0}, {1, -5}, {math.MaxInt64, 10}} {
+		q := newSingleQuery[bson.M](unreachableCollection(t), time.Second, logger.Nop(), nil)
+		docs, err := q.FindPaginated(bson.M{}, c.page, c.limit, nil)
+		assert.ErrorIs(t, err, ErrInvalidPage)
+		assert.Nil(t, docs)
+
+		q = newSingleQuery[bson.M](unreachableCollection(t), time.Second, logger.Nop(), nil)
+		docs, total, err := q.FindPaginatedWithCount(bson.M{}, c.page, c.limit, nil)
+		assert.ErrorIs(t, err, ErrInvalidPage)
+		assert.Nil(t, docs)
+		assert.Zero(t, total)
+	}
+}
+
+func TestKeysetPosition(t *testing.T) {
+	id := primitive.NewObjectID()
+	doc, err := bson.Marshal(bson.M{"_id": id, "meta": bson.M{"score": 7}})
+	assert.NoError(t, err)
+
+	payload, err := newKeysetPosition(doc, "meta.score")
+	assert.NoError(t, err)
+
+	var position keysetPosition
+	assert.NoError(t, bson.Unmarshal(payload, &position))
+	assert.Equal(t, int32(7), position.Value.Int32())
+	assert.Equal(t, id, position.Id.ObjectID())
+
+	filter := position.filter("meta.score", -1)
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"meta.score": bson.M{"$lt": position.Value}},
+		bson.M{"meta.score": position.Value, "_id": bson.M{"$lt": position.Id}},
+	}}, filter)
+
+	assert.Equal(t, bson.M{"_id": bson.M{"$gt": position.Id}}, position.filter("_id", 1))
+
+	_, err = newKeysetPosition(doc, "missing")
+	assert.Error(t, err)
+}
+
+func TestQuery_FindAfter(t *testing.T) {
+	q := newSingleQuery[bson.M](unreachableCollection(t), time.Second, logger.Nop(), nil)
+	_, _, err := q.FindAfter(bson.M{}, "createdAt", -1, nil, 10, nil)
+	assert.Error(t, err)
+
+	q = newSingleQuery[bson.M](unreachableCollection(t), time.Second, logger.Nop(), nil)
+	_, _, err = q.FindAfter(bson.M{}, "", 1, []byte("invalid"), 10, nil)
+	assert.ErrorContains(t, err, "error decoding position")
+}
+
+func TestQuery_FindAfterInvalidLimit(t *testing.T) {
+	for _, limit := range []int64{0, -1} {
+		q := newSingleQuery[bson.M](unreachableCollection(t), time.Second, logger.Nop(), nil)
+		docs, next, err := q.FindAfter(bson.M{}, "createdAt", -1, nil, limit, nil)
+		assert.ErrorIs(t, err, ErrInvalidPage)
+		assert.Nil(t, docs)
+		assert.Nil(t, next)
+	}
+}
+
+func TestQuery_Stream(t *testing.T) {
+	q := newQuery[bson.M](context.Background(), unreachableCollection(t), logger.Nop(), nil)
+
+	var count int
+	var errs []error
+	for doc, err := range q.Stream(context.Background(), bson.M{}, nil) {
+		count++
+		assert.Nil(t, doc)
+		errs = append(errs, err)
+	}
+
+	assert.Equal(t, 1, count)
+	assert.ErrorContains(t, errs[0], "error executing query")
+}
+
+func TestQuery_StreamOutlivesQueryTimeout(t *testing.T) {
+	q := newSingleQuery[bson.M](unreachableCollection(t), time.Millisecond, logger.Nop(), nil)
+	time.Sleep(5 * time.Millisecond)
+
+	// the query context has expired, the stream runs under its own
+	var errs []error
+	for _, err := range q.Stream(context.Background(), bson.M{}, nil) {
+		errs = append(errs, err)
+	}
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "server selection")
+	assert.NotErrorIs(t, errs[0], context.DeadlineExceeded)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	q = newSingleQuery[bson.M](unreachableCollection(t), time.Minute, logger.Nop(), nil)
+	for _, err := range q.Stream(ctx, bson.M{}, nil) {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
+
+func TestQuery_StreamNotRanged(t *testing.T) {
+	q := newSingleQuery[bson.M](unreachableCollection(t), time.Minute, logger.Nop(), nil).(*query[bson.M])
+	q.Stream(context.Background(), bson.M{}, nil)
+	assert.ErrorIs(t, q.context.Err(), context.Canceled)
+}
+
+func TestQuery_StreamBreak(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("closes cursor", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(7, "test.blogs", mtest.FirstBatch,
+				bson.D{{Key: "n", Value: 1}}, bson.D{{Key: "n", Value: 2}}),
+			mtest.CreateSuccessResponse(),
+		)
+		q := newQuery[bson.M](context.Background(), mt.Coll, logger.Nop(), nil)
+
+		var docs []*bson.M
+		for doc, err := range q.Stream(context.Background(), bson.M{}, nil) {
+			assert.NoError(mt, err)
+			docs = append(docs, doc)
+			break
+		}
+		assert.Equal(mt, []*bson.M{{"n": int32(1)}}, docs)
+
+		var commands []string
+		for _, e := range mt.GetAllStartedEvents() {
+			commands = append(commands, e.CommandName)
+		}
+		assert.Equal(mt, []string{"find", "killCursors"}, commands)
+	})
+}
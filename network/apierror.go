@@ -8,6 +8,7 @@ import (
 
 type apiError struct {
 	Code    int
+	ResCode ResCode
 	Message string
 	Err     error
 }
@@ -16,6 +17,10 @@ func (e *apiError) GetCode() int {
 	return e.Code
 }
 
+func (e *apiError) GetResCode() ResCode {
+	return e.ResCode
+}
+
 func (e *apiError) GetMessage() string {
 	return e.Message
 }
@@ -31,9 +36,18 @@ func (e *apiError) Unwrap() error {
 	return e.Err
 }
 
-func newApiError(code int, message string, err error) ApiError {
+// NewApiError creates an error for any status code. sendError responds with
+// the code and message for 4xx and 5xx codes; anything else becomes a 500.
+func NewApiError(code int, message string, err error) ApiError {
+	return NewApiErrorWithResCode(code, FailureCode, message, err)
+}
+
+// NewApiErrorWithResCode lets clients tell apart errors sharing a status,
+// e.g. an expired token from a missing one.
+func NewApiErrorWithResCode(code int, rescode ResCode, message string, err error) ApiError {
 	apiError := apiError{
 		Code:    code,
+		ResCode: rescode,
 		Message: message,
 		Err:     err,
 	}
@@ -44,21 +58,41 @@ func newApiError(code int, message string, err error) ApiError {
 }
 
 func NewBadRequestError(message string, err error) ApiError {
-	return newApiError(http.StatusBadRequest, message, err)
+	return NewApiError(http.StatusBadRequest, message, err)
 }
 
 func NewForbiddenError(message string, err error) ApiError {
-	return newApiError(http.StatusForbidden, message, err)
+	return NewApiError(http.StatusForbidden, message, err)
 }
 
 func NewUnauthorizedError(message string, err error) ApiError {
-	return newApiError(http.StatusUnauthorized, message, err)
+	return NewApiError(http.StatusUnauthorized, message, err)
 }
 
 func NewNotFoundError(message string, err error) ApiError {
-	return newApiError(http.StatusNotFound, message, err)
+	return NewApiError(http.StatusNotFound, message, err)
 }
 
 func NewInternalServerError(message string, err error) ApiError {
-	return newApiError(http.StatusInternalServerError, message, err)
+	return NewApiError(http.StatusInternalServerError, message, err)
+}
+
+func NewConflictError(message string, err error) ApiError {
+	return NewApiError(http.StatusConflict, message, err)
+}
+
+func NewGoneError(message string, err error) ApiError {
+	return NewApiError(http.StatusGone, message, err)
+}
+
+func NewUnprocessableEntityError(message string, err error) ApiError {
+	return NewApiError(http.StatusUnprocessableEntity, message, err)
+}
+
+func NewTooManyRequestsError(message string, err error) ApiError {
+	return NewApiError(http.StatusTooManyRequests, message, err)
+}
+
+func NewServiceUnavailableError(message string, err error) ApiError {
+	return NewApiError(http.StatusServiceUnavailable, message, err)
 }
@@ -63,3 +63,29 @@ func TestNewInternalServerError(t *testing.T) {
 	assert.EqualError(t, apiErr, fmt.Sprintf("%d - %s: %v", http.StatusInternalServerError, message, err))
 	assert.ErrorIs(t, apiErr, err)
 }
+
+func TestNewApiError(t *testing.T) {
+	apiErr := NewApiError(http.StatusPaymentRequired, "Payment required", nil)
+
+	assert.Equal(t, http.StatusPaymentRequired, apiErr.GetCode())
+	assert.Equal(t, "Payment required", apiErr.GetMessage())
+	assert.EqualError(t, apiErr.Unwrap(), "Payment required")
+}
+
+func TestNewStatusErrors(t *testing.T) {
+	err := errors.New("underlying error")
+	cases := map[int]func(string, error) ApiError{
+		http.StatusConflict:            NewConflictError,
+		http.StatusGone:                NewGoneError,
+		http.StatusUnprocessableEntity: NewUnprocessableEntityError,
+		http.StatusTooManyRequests:     NewTooManyRequestsError,
+		http.StatusServiceUnavailable:  NewServiceUnavailableError,
+	}
+
+	for code, newError := range cases {
+		apiErr := newError("message", err)
+		assert.Equal(t, code, apiErr.GetCode())
+		assert.Equal(t, "message", apiErr.GetMessage())
+		assert.ErrorIs(t, apiErr, err)
+	}
+}
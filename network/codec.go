@@ -0,0 +1,246 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+)
+
+const codecsKey = "codecs"
+
+var ErrUnsupportedValue = errors.New("value not supported by codec")
+
+// Codec renders responses and binds request bodies for the media types it
+// lists, the first being the Content-Type it responds with.
+type Codec interface {
+	ContentTypes() []string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// Payload is implemented by responses so codecs without an envelope, like
+// protobuf, can encode the data alone.
+type Payload interface {
+	Payload() any
+}
+
+type jsonCodec struct{}
+
+func NewJSONCodec() Codec {
+	return jsonCodec{}
+}
+
+func (jsonCodec) ContentTypes() []string {
+	return []string{"application/json"}
+}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+type xmlCodec struct{}
+
+// NewXMLCodec encodes responses under a <response> root element. Maps are
+// not supported by encoding/xml, so data should be structs.
+func NewXMLCodec() Codec {
+	return xmlCodec{}
+}
+
+func (xmlCodec) ContentTypes() []string {
+	return []string{"application/xml", "text/xml"}
+}
+
+func (xmlCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	if err := xml.NewEncoder(&buf).EncodeElement(v, xml.StartElement{Name: xml.Name{Local: "response"}}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (xmlCodec) Unmarshal(data []byte, v any) error {
+	return xml.Unmarshal(data, v)
+}
+
+type msgPackCodec struct {
+	handle *codec.MsgpackHandle
+}
+
+// NewMsgPackCodec uses the json struct tags for field names, so payloads
+// look the same as their JSON form.
+func NewMsgPackCodec() Codec {
+	handle := &codec.MsgpackHandle{}
+	handle.TypeInfos = codec.NewTypeInfos([]string{"codec", "json"})
+	handle.WriteExt = true
+	return msgPackCodec{handle: handle}
+}
+
+func (msgPackCodec) ContentTypes() []string {
+	return []string{"application/msgpack", "application/x-msgpack"}
+}
+
+func (c msgPackCodec) Marshal(v any) ([]byte, error) {
+	var out []byte
+	err := codec.NewEncoderBytes(&out, c.handle).Encode(v)
+	return out, err
+}
+
+func (c msgPackCodec) Unmarshal(data []byte, v any) error {
+	return codec.NewDecoderBytes(data, c.handle).Decode(v)
+}
+
+type protobufCodec struct{}
+
+// NewProtobufCodec encodes proto.Message values. There is no protobuf
+// envelope, so only the response data is sent; responses whose data is
+// not a proto.Message, including errors, fall back to JSON.
+func NewProtobufCodec() Codec {
+	return protobufCodec{}
+}
+
+func (protobufCodec) ContentTypes() []string {
+	return []string{"application/x-protobuf", "application/protobuf"}
+}
+
+func (protobufCodec) Marshal(v any) ([]byte, error) {
+	if p, ok := v.(Payload); ok {
+		v = p.Payload()
+	}
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, ErrUnsupportedValue
+	}
+	return proto.Marshal(m)
+}
+
+func (protobufCodec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return ErrUnsupportedValue
+	}
+	return proto.Unmarshal(data, m)
+}
+
+func SetCodecs(ctx *gin.Context, codecs []Codec) {
+	ctx.Set(codecsKey, codecs)
+}
+
+func codecsHandler(codecs []Codec) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		SetCodecs(ctx, codecs)
+		ctx.Next()
+	}
+}
+
+func contextCodecs(ctx *gin.Context) []Codec {
+	codecs, _ := ctx.Get(codecsKey)
+	c, _ := codecs.([]Codec)
+	return c
+}
+
+func findCodec(codecs []Codec, mediaType string) Codec {
+	for _, c := range codecs {
+		for _, t := range c.ContentTypes() {
+			if t == mediaType {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// negotiateCodec picks the registered codec the client prefers per Accept,
+// nil meaning JSON.
+func negotiateCodec(ctx *gin.Context) Codec {
+	codecs := contextCodecs(ctx)
+	if len(codecs) == 0 || ctx.Request == nil {
+		return nil
+	}
+
+	type accepted struct {
+		mediaType string
+		q         float64
+	}
+	var types []accepted
+	for _, part := range strings.Split(ctx.GetHeader("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			types = append(types, accepted{mediaType, q})
+		}
+	}
+	sort.SliceStable(types, func(i, j int) bool { return types[i].q > types[j].q })
+
+	for _, t := range types {
+		if t.mediaType == "*/*" || t.mediaType == "application/json" {
+			return nil
+		}
+		if c := findCodec(codecs, t.mediaType); c != nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// requestCodec returns the codec registered for the request Content-Type,
+// nil meaning JSON.
+func requestCodec(ctx *gin.Context) Codec {
+	codecs := contextCodecs(ctx)
+	if len(codecs) == 0 {
+		return nil
+	}
+	c := findCodec(codecs, ctx.ContentType())
+	if _, ok := c.(jsonCodec); ok {
+		return nil
+	}
+	return c
+}
+
+// codecBinding adapts a Codec to gin binding so decoded bodies go through
+// the same validation as JSON ones.
+type codecBinding struct {
+	codec Codec
+}
+
+func (b codecBinding) Name() string {
+	return b.codec.ContentTypes()[0]
+}
+
+func (b codecBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return errors.New("invalid request")
+	}
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	if err := b.codec.Unmarshal(data, obj); err != nil {
+		return err
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
@@ -0,0 +1,178 @@
+package network
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func serveWithCodecs(t *testing.T, method, accept, contentType string, body []byte, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/", bytes.NewReader(body))
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	config := RouterConfig{Codecs: []Codec{NewXMLCodec(), NewMsgPackCodec(), NewProtobufCodec()}}
+	return MockTestRouter(t, config, func(r Router) {
+		r.GetEngine().Handle(method, "/", handler)
+	}, req)
+}
+
+func TestCodec_DefaultsToJSON(t *testing.T) {
+	rr := serveWithCodecs(t, http.MethodGet, "", "", nil, func(ctx *gin.Context) {
+		SendSuccessDataResponse(ctx, "found", &MockPayload{Field: "value"})
+	})
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "application/json")
+	assert.JSONEq(t, `{"code":"10000","status":200,"message":"found","data":{"field":"value"}}`, rr.Body.String())
+}
+
+func TestCodec_XMLResponse(t *testing.T) {
+	rr := serveWithCodecs(t, http.MethodGet, "text/xml", "", nil, func(ctx *gin.Context) {
+		SendSuccessDataResponse(ctx, "found", &MockPayload{Field: "value"})
+	})
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/xml", rr.Header().Get("Content-Type"))
+
+	var res struct {
+		XMLName xml.Name `xml:"response"`
+		Code    string   `xml:"code"`
+		Message string   `xml:"message"`
+		Field   string   `xml:"data>Field"`
+	}
+	assert.NoError(t, xml.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "10000", res.Code)
+	assert.Equal(t, "found", res.Message)
+	assert.Equal(t, "value", res.Field)
+}
+
+func TestCodec_XMLErrorResponse(t *testing.T) {
+	rr := serveWithCodecs(t, http.MethodGet, "application/xml", "", nil, func(ctx *gin.Context) {
+		SendBadRequestError(ctx, "bad input", nil)
+	})
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "application/xml", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), "<message>bad input</message>")
+}
+
+func TestCodec_MsgPackResponse(t *testing.T) {
+	rr := serveWithCodecs(t, http.MethodGet, "application/x-msgpack", "", nil, func(ctx *gin.Context) {
+		SendSuccessDataResponse(ctx, "found", &MockPayload{Field: "value"})
+	})
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/msgpack", rr.Header().Get("Content-Type"))
+
+	var res map[string]any
+	assert.NoError(t, NewMsgPackCodec().Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "found", res["message"])
+	assert.Equal(t, "value", res["data"].(map[any]any)["field"])
+}
+
+func TestCodec_ProtobufResponse(t *testing.T) {
+	rr := serveWithCodecs(t, http.MethodGet, "application/x-protobuf", "", nil, func(ctx *gin.Context) {
+		SendSuccessDataResponse(ctx, "found", wrapperspb.String("value"))
+	})
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/x-protobuf", rr.Header().Get("Content-Type"))
+
+	var res wrapperspb.StringValue
+	assert.NoError(t, proto.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "value", res.GetValue())
+}
+
+func TestCodec_ProtobufFallsBackToJSON(t *testing.T) {
+	rr := serveWithCodecs(t, http.MethodGet, "application/x-protobuf", "", nil, func(ctx *gin.Context) {
+		SendNotFoundError(ctx, "missing", nil)
+	})
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "application/json")
+	assert.Contains(t, rr.Body.String(), `"message":"missing"`)
+}
+
+func TestCodec_UnencodableDataFallsBackToJSON(t *testing.T) {
+	rr := serveWithCodecs(t, http.MethodGet, "application/xml", "", nil, func(ctx *gin.Context) {
+		SendSuccessDataResponse(ctx, "found", &map[string]string{"field": "value"})
+	})
+
+	// encoding/xml can not encode maps, so Accept is not honoured
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "application/json")
+	assert.Equal(t, "Accept", rr.Header().Get("Vary"))
+	assert.JSONEq(t, `{"code":"10000","status":200,"message":"found","data":{"field":"value"}}`, rr.Body.String())
+}
+
+func TestCodec_VaryAccept(t *testing.T) {
+	for _, accept := range []string{"", "application/json", "application/msgpack"} {
+		rr := serveWithCodecs(t, http.MethodGet, accept, "", nil, func(ctx *gin.Context) {
+			ctx.Header("Vary", "Origin, accept")
+			SendSuccessMsgResponse(ctx, "ok")
+		})
+		assert.Equal(t, []string{"Origin, accept"}, rr.Header().Values("Vary"), accept)
+
+		rr = serveWithCodecs(t, http.MethodGet, accept, "", nil, MockSuccessMsgHandler("ok"))
+		assert.Equal(t, []string{"Accept"}, rr.Header().Values("Vary"), accept)
+	}
+
+	rr := MockTestHandler(t, http.MethodGet, "/", "/", "", MockSuccessMsgHandler("ok"), nil)
+	assert.Empty(t, rr.Header().Get("Vary"))
+}
+
+func TestCodec_AcceptQuality(t *testing.T) {
+	rr := serveWithCodecs(t, http.MethodGet, "application/xml;q=0.5, application/msgpack", "", nil, func(ctx *gin.Context) {
+		SendSuccessMsgResponse(ctx, "ok")
+	})
+	assert.Equal(t, "application/msgpack", rr.Header().Get("Content-Type"))
+
+	rr = serveWithCodecs(t, http.MethodGet, "application/xml;q=0, */*;q=0.1", "", nil, func(ctx *gin.Context) {
+		SendSuccessMsgResponse(ctx, "ok")
+	})
+	assert.Contains(t, rr.Header().Get("Content-Type"), "application/json")
+}
+
+func TestCodec_ReqBodyMsgPack(t *testing.T) {
+	body, err := NewMsgPackCodec().Marshal(map[string]string{"field": "value"})
+	assert.NoError(t, err)
+
+	rr := serveWithCodecs(t, http.MethodPost, "", "application/msgpack", body, func(ctx *gin.Context) {
+		payload, err := ReqBody[MockPayload](ctx)
+		if err != nil {
+			SendBadRequestError(ctx, err.Error(), err)
+			return
+		}
+		SendSuccessDataResponse(ctx, "ok", payload)
+	})
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"field":"value"`)
+}
+
+func TestCodec_ReqBodyXMLValidation(t *testing.T) {
+	body := []byte(`<MockPayload><Field>x</Field></MockPayload>`)
+
+	rr := serveWithCodecs(t, http.MethodPost, "", "application/xml", body, func(ctx *gin.Context) {
+		payload, err := ReqBody[MockPayload](ctx)
+		if err != nil {
+			SendBadRequestError(ctx, err.Error(), err)
+			return
+		}
+		SendSuccessDataResponse(ctx, "ok", payload)
+	})
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"tag":"min"`)
+}
@@ -0,0 +1,94 @@
+package network
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const envelopeKey = "envelope"
+
+// Body holds what a response carries, independent of its JSON shape.
+type Body struct {
+	ResCode ResCode
+	Status  int
+	Message string
+	Data    any
+	Errors  []FieldError
+}
+
+// Envelope builds the JSON document written for every response, replacing
+// the default {code,status,message,data} shape. Problem documents are
+// sent as they are.
+type Envelope interface {
+	Wrap(ctx *gin.Context, body *Body) any
+}
+
+type EnvelopeFunc func(ctx *gin.Context, body *Body) any
+
+func (f EnvelopeFunc) Wrap(ctx *gin.Context, body *Body) any {
+	return f(ctx, body)
+}
+
+func SetEnvelope(ctx *gin.Context, envelope Envelope) {
+	ctx.Set(envelopeKey, envelope)
+}
+
+func envelopeHandler(envelope Envelope) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		SetEnvelope(ctx, envelope)
+		ctx.Next()
+	}
+}
+
+func responseErrors(response any) []FieldError {
+	if fe, ok := response.(fieldErrorer); ok {
+		return fe.GetErrors()
+	}
+	return nil
+}
+
+func writeResponse[T any](ctx *gin.Context, response Response[T]) {
+	var value any = response
+	if envelope, ok := ctx.Get(envelopeKey); ok {
+		body := &Body{
+			ResCode: response.GetResCode(),
+			Status:  response.GetStatus(),
+			Message: response.GetMessage(),
+			Errors:  responseErrors(response),
+		}
+		if data := response.GetData(); data != nil {
+			body.Data = data
+		}
+		value = envelope.(Envelope).Wrap(ctx, body)
+	}
+
+	if len(contextCodecs(ctx)) > 0 {
+		// the body depends on Accept, so shared caches must key on it
+		varyAccept(ctx)
+	}
+	if c := negotiateCodec(ctx); c != nil {
+		if _, ok := c.(jsonCodec); !ok {
+			if data, err := c.Marshal(value); err == nil {
+				ctx.Data(response.GetStatus(), c.ContentTypes()[0], data)
+				return
+			}
+		}
+	}
+
+	// JSON is the default and the fallback for values the negotiated codec
+	// can not encode, so the Accept of the client is then not honoured
+	ctx.JSON(response.GetStatus(), value)
+}
+
+func varyAccept(ctx *gin.Context) {
+	header := ctx.Writer.Header()
+	for _, v := range header.Values("Vary") {
+		for _, name := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(name), "Accept") {
+				return
+			}
+		}
+	}
+	header.Add("Vary", "Accept")
+}
@@ -0,0 +1,82 @@
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEnvelope struct {
+	Code      ResCode      `json:"code"`
+	Message   string       `json:"message"`
+	Timestamp string       `json:"timestamp"`
+	RequestId string       `json:"requestId"`
+	Data      any          `json:"data,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+func serveWithEnvelope(t *testing.T, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	envelope := EnvelopeFunc(func(ctx *gin.Context, body *Body) any {
+		return &testEnvelope{
+			Code:      body.ResCode,
+			Message:   body.Message,
+			Timestamp: time.Unix(0, 0).UTC().Format(time.RFC3339),
+			RequestId: RequestId(ctx),
+			Data:      body.Data,
+			Errors:    body.Errors,
+		}
+	})
+
+	return MockTestRouter(t, RouterConfig{Envelope: envelope}, func(r Router) {
+		r.GetEngine().GET("/", func(ctx *gin.Context) {
+			SetRequestId(ctx, "req-1")
+			handler(ctx)
+		})
+	}, httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestEnvelope_Success(t *testing.T) {
+	rr := serveWithEnvelope(t, func(ctx *gin.Context) {
+		SendSuccessDataResponse(ctx, "found", &MockPayload{Field: "value"})
+	})
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{
+		"code": "10000",
+		"message": "found",
+		"timestamp": "1970-01-01T00:00:00Z",
+		"requestId": "req-1",
+		"data": {"field": "value"}
+	}`, rr.Body.String())
+}
+
+func TestEnvelope_NoData(t *testing.T) {
+	rr := serveWithEnvelope(t, func(ctx *gin.Context) {
+		SendSuccessMsgResponse(ctx, "done")
+	})
+
+	var body map[string]any
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.NotContains(t, body, "data")
+}
+
+func TestEnvelope_Error(t *testing.T) {
+	rr := serveWithEnvelope(t, func(ctx *gin.Context) {
+		_, err := ValidateDto(&MockPayload{})
+		SendBadRequestError(ctx, err.Error(), err)
+	})
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.JSONEq(t, `{
+		"code": "10001",
+		"message": "field is required",
+		"timestamp": "1970-01-01T00:00:00Z",
+		"requestId": "req-1",
+		"errors": [{"field": "field", "tag": "required", "message": "field is required"}]
+	}`, rr.Body.String())
+}
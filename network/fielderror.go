@@ -0,0 +1,86 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/afteracademy/goserve/v2/utility"
+	"github.com/go-playground/validator/v10"
+)
+
+type FieldError struct {
+	Field   string `json:"field" xml:"field"`
+	Tag     string `json:"tag" xml:"tag"`
+	Param   string `json:"param,omitempty" xml:"param,omitempty"`
+	Message string `json:"message" xml:"message"`
+}
+
+// ValidationError keeps the failed fields of a request so error responses
+// can list them. Error returns the messages joined, as sent in "message".
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, f := range e.Errors {
+		msgs = append(msgs, f.Message)
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// messages override the default ones when a DtoV returns one per error
+func newValidationError(errs validator.ValidationErrors, messages []string) *ValidationError {
+	fields := make([]FieldError, 0, len(errs))
+	for i, e := range errs {
+		message := utility.FormatFieldError(e)
+		if len(messages) == len(errs) {
+			message = messages[i]
+		}
+		fields = append(fields, FieldError{
+			Field:   e.Field(),
+			Tag:     e.Tag(),
+			Param:   e.Param(),
+			Message: message,
+		})
+	}
+	return &ValidationError{Errors: fields}
+}
+
+func fieldErrors(err error) []FieldError {
+	var validationError *ValidationError
+	if errors.As(err, &validationError) {
+		return validationError.Errors
+	}
+	return nil
+}
+
+// newTypeError reports a sent value of field that could not be converted
+// to typ while binding.
+func newTypeError(field string, typ string) FieldError {
+	return FieldError{
+		Field:   field,
+		Tag:     "type",
+		Param:   typ,
+		Message: fmt.Sprintf(utility.Messages["type"], strings.ToLower(field), typ),
+	}
+}
+
+// mergeFieldErrors adds the failed fields of the validation err to the
+// binding ones, skipping fields that were not bound as their value is not
+// the one sent.
+func mergeFieldErrors(bindErrs []FieldError, err error) *ValidationError {
+	failed := make(map[string]bool, len(bindErrs))
+	for _, e := range bindErrs {
+		failed[e.Field] = true
+	}
+	fields := slices.Clone(bindErrs)
+	for _, e := range fieldErrors(err) {
+		if !failed[e.Field] {
+			fields = append(fields, e)
+		}
+	}
+	return &ValidationError{Errors: fields}
+}
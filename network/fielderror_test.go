@@ -0,0 +1,110 @@
+package network
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type fieldErrorPayload struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+	Age   int    `json:"age" validate:"gte=18"`
+}
+
+func TestValidateDto_FieldErrors(t *testing.T) {
+	_, err := ValidateDto(&fieldErrorPayload{Email: "invalid", Age: 10})
+
+	var validationError *ValidationError
+	assert.True(t, errors.As(err, &validationError))
+	assert.Equal(t, []FieldError{
+		{Field: "name", Tag: "required", Message: "name is required"},
+		{Field: "email", Tag: "email", Message: "email is not a valid email"},
+		{Field: "age", Tag: "gte", Param: "18", Message: "age must be greater than or equal to 18"},
+	}, validationError.Errors)
+	assert.Equal(t, "name is required, email is not a valid email, age must be greater than or equal to 18", err.Error())
+}
+
+type customMessagesPayload struct {
+	Name string `json:"name" validate:"required"`
+	Age  int    `json:"age" validate:"gte=18"`
+}
+
+func (p *customMessagesPayload) GetValue() *customMessagesPayload {
+	return p
+}
+
+func (p *customMessagesPayload) ValidateErrors(errs validator.ValidationErrors) ([]string, error) {
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msgs = append(msgs, "bad "+e.Field())
+	}
+	return msgs, nil
+}
+
+type summaryMessagePayload struct {
+	customMessagesPayload
+}
+
+func (p *summaryMessagePayload) GetValue() *summaryMessagePayload {
+	return p
+}
+
+func (p *summaryMessagePayload) ValidateErrors(errs validator.ValidationErrors) ([]string, error) {
+	return []string{"request is invalid"}, nil
+}
+
+func TestValidateDto_DtoVFieldErrors(t *testing.T) {
+	_, err := ValidateDto(&customMessagesPayload{})
+	assert.Equal(t, "bad name, bad age", err.Error())
+
+	var validationError *ValidationError
+	assert.True(t, errors.As(err, &validationError))
+	assert.Equal(t, "bad name", validationError.Errors[0].Message)
+
+	_, err = ValidateDto(&summaryMessagePayload{})
+	assert.Equal(t, "request is invalid", err.Error())
+	assert.True(t, errors.As(err, &validationError))
+	assert.Len(t, validationError.Errors, 2)
+	assert.Equal(t, "name is required", validationError.Errors[0].Message)
+}
+
+func TestSend_ValidationErrors(t *testing.T) {
+	body := `{"field": "t"}`
+
+	mockHandler := func(ctx *gin.Context) {
+		_, err := ReqBody[MockPayload](ctx)
+		SendBadRequestError(ctx, err.Error(), err)
+	}
+
+	rr := MockTestHandler(t, "POST", "/mock", "/mock", body, mockHandler, nil)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"message":"field must be at least 2 characters"`)
+	assert.Contains(t, rr.Body.String(), `"errors":[{"field":"field","tag":"min","param":"2","message":"field must be at least 2 characters"}]`)
+}
+
+func TestSend_NoFieldErrors(t *testing.T) {
+	rr := MockTestHandler(t, "GET", "/mock", "/mock", "", func(ctx *gin.Context) {
+		SendBadRequestError(ctx, "bad", nil)
+	}, nil)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.NotContains(t, rr.Body.String(), `"errors"`)
+}
+
+func TestSend_ApiErrorResCode(t *testing.T) {
+	tokenExpired := ResCode("10003")
+
+	rr := MockTestHandler(t, "GET", "/mock", "/mock", "", func(ctx *gin.Context) {
+		SendMixedError(ctx, NewApiErrorWithResCode(http.StatusUnauthorized, tokenExpired, "token expired", nil))
+	}, nil)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"code":"10003"`)
+	assert.Contains(t, rr.Body.String(), `"message":"token expired"`)
+}
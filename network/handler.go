@@ -0,0 +1,164 @@
+package network
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/afteracademy/goserve/v2/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+// HandlerFunc handles a request bound and validated into Req. A nil
+// response sends the success message only and errors go through
+// SendMixedError, so ApiErrors keep their status.
+type HandlerFunc[Req any, Res any] func(ctx *gin.Context, req *Req) (*Res, error)
+
+type routeConfig struct {
+	middlewares []gin.HandlerFunc
+	auth        bool
+	roles       []string
+	doc         RouteDoc
+	status      int
+	message     string
+}
+
+type RouteOption func(*routeConfig)
+
+// Use runs middlewares before the handler.
+func Use(middlewares ...gin.HandlerFunc) RouteOption {
+	return func(c *routeConfig) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// Authenticated runs the controller Authentication before the handler and
+// documents the route as requiring it.
+func Authenticated(controller Controller) RouteOption {
+	return func(c *routeConfig) {
+		c.middlewares = append(c.middlewares, controller.Authentication())
+		c.auth = true
+	}
+}
+
+// Authorized runs the controller Authorization of role before the handler
+// and documents the role. Use it after Authenticated.
+func Authorized(controller Controller, role string) RouteOption {
+	return func(c *routeConfig) {
+		c.middlewares = append(c.middlewares, controller.Authorization(role))
+		c.roles = append(c.roles, role)
+	}
+}
+
+// Doc adds the OpenAPI details of the route. Input and response types are
+// taken from the handler unless set.
+func Doc(doc RouteDoc) RouteOption {
+	return func(c *routeConfig) {
+		c.doc = doc
+	}
+}
+
+// Status sets the success status, 200 by default.
+func Status(status int) RouteOption {
+	return func(c *routeConfig) {
+		c.status = status
+	}
+}
+
+// Message sets the success message, "success" by default.
+func Message(message string) RouteOption {
+	return func(c *routeConfig) {
+		c.message = message
+	}
+}
+
+func GET[Req any, Res any](group *gin.RouterGroup, path string, handler HandlerFunc[Req, Res], options ...RouteOption) {
+	Handle(group, http.MethodGet, path, handler, options...)
+}
+
+func POST[Req any, Res any](group *gin.RouterGroup, path string, handler HandlerFunc[Req, Res], options ...RouteOption) {
+	Handle(group, http.MethodPost, path, handler, options...)
+}
+
+func PUT[Req any, Res any](group *gin.RouterGroup, path string, handler HandlerFunc[Req, Res], options ...RouteOption) {
+	Handle(group, http.MethodPut, path, handler, options...)
+}
+
+func PATCH[Req any, Res any](group *gin.RouterGroup, path string, handler HandlerFunc[Req, Res], options ...RouteOption) {
+	Handle(group, http.MethodPatch, path, handler, options...)
+}
+
+func DELETE[Req any, Res any](group *gin.RouterGroup, path string, handler HandlerFunc[Req, Res], options ...RouteOption) {
+	Handle(group, http.MethodDelete, path, handler, options...)
+}
+
+// Handle registers a typed handler. Req is bound from the body, query,
+// headers and uri params by its json, form, header and uri tags and
+// validated with ValidateDto; use struct{} for routes without input.
+//
+//	network.POST(group, "/", c.create,
+//		network.Authenticated(c), network.Authorized(c, "admin"),
+//		network.Status(http.StatusCreated),
+//		network.Doc(network.RouteDoc{Summary: "create blog"}),
+//	)
+func Handle[Req any, Res any](group *gin.RouterGroup, method string, path string, handler HandlerFunc[Req, Res], options ...RouteOption) {
+	config := routeConfig{status: http.StatusOK, message: "success"}
+	for _, option := range options {
+		option(&config)
+	}
+
+	handlers := append(config.middlewares, func(ctx *gin.Context) {
+		req, err := ReqAll[Req](ctx)
+		if err != nil {
+			SendBadRequestError(ctx, err.Error(), err)
+			return
+		}
+
+		res, err := handler(ctx, req)
+		if err != nil {
+			SendMixedError(ctx, err)
+			return
+		}
+		if ctx.Writer.Written() {
+			// the handler wrote its own response
+			return
+		}
+		if res == nil {
+			SendCustomResponse[any](ctx, SuccessCode, config.status, config.message, nil)
+			return
+		}
+		SendCustomResponse(ctx, SuccessCode, config.status, config.message, res)
+	})
+	Describe(group, method, path, describeTypes[Req, Res](method, config), handlers...)
+}
+
+func describeTypes[Req any, Res any](method string, config routeConfig) RouteDoc {
+	doc := config.doc
+	doc.Auth = doc.Auth || config.auth
+	doc.Roles = append(doc.Roles[:len(doc.Roles):len(doc.Roles)], config.roles...)
+	if doc.Status == 0 {
+		doc.Status = config.status
+	}
+
+	req := reflect.TypeFor[Req]()
+	if req.Kind() == reflect.Struct && req.NumField() > 0 {
+		sample := new(Req)
+		if doc.Params == nil && len(tagNames(req, "uri")) > 0 {
+			doc.Params = sample
+		}
+		if doc.Query == nil && len(tagNames(req, "form")) > 0 {
+			doc.Query = sample
+		}
+		if doc.Headers == nil && len(tagNames(req, "header")) > 0 {
+			doc.Headers = sample
+		}
+		if doc.Body == nil && method != http.MethodGet && method != http.MethodDelete && openapi.HasBody(req) {
+			doc.Body = sample
+		}
+	}
+
+	res := reflect.TypeFor[Res]()
+	if doc.Response == nil && !(res.Kind() == reflect.Struct && res.NumField() == 0) {
+		doc.Response = new(Res)
+	}
+	return doc
+}
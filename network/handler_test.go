@@ -0,0 +1,180 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/afteracademy/goserve/v2/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type updateReq struct {
+	Id      string `uri:"id" validate:"required,len=3"`
+	Notify  bool   `form:"notify"`
+	Tenant  string `header:"X-Tenant-Id" validate:"required"`
+	Title   string `json:"title" validate:"required,min=2"`
+	Content string `json:"content"`
+}
+
+type updateRes struct {
+	Id     string `json:"id"`
+	Title  string `json:"title"`
+	Notify bool   `json:"notify"`
+	Tenant string `json:"tenant"`
+}
+
+type roleReq struct {
+	Role   string `json:"role"`
+	Name   string
+	Tenant string `header:"X-Tenant-Id"`
+	Page   int    `form:"page,default=1"`
+}
+
+type typedController struct {
+	Controller
+}
+
+func (c *typedController) MountRoutes(group *gin.RouterGroup) {
+	PUT(group, "/:id", func(ctx *gin.Context, req *updateReq) (*updateRes, error) {
+		return &updateRes{Id: req.Id, Title: req.Title, Notify: req.Notify, Tenant: req.Tenant}, nil
+	}, Message("updated"), Doc(RouteDoc{Summary: "update"}))
+
+	POST(group, "/", func(ctx *gin.Context, req *MockPayload) (*MockPayload, error) {
+		return req, nil
+	}, Status(http.StatusCreated), Use(func(ctx *gin.Context) {
+		if ctx.GetHeader("X-Block") != "" {
+			SendForbiddenError(ctx, "blocked", nil)
+			return
+		}
+		ctx.Next()
+	}))
+
+	POST(group, "/roles/:Role", func(ctx *gin.Context, req *roleReq) (*roleReq, error) {
+		return req, nil
+	})
+
+	DELETE(group, "/:id", func(ctx *gin.Context, req *struct{}) (*struct{}, error) {
+		switch ctx.Param("id") {
+		case "missing":
+			return nil, NewNotFoundError("blog not found", nil)
+		case "broken":
+			return nil, errors.New("db down")
+		}
+		return nil, nil
+	}, Message("deleted"))
+}
+
+func serveTyped(t *testing.T, method, url, body string, headers map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return MockTestRouter(t, RouterConfig{OpenAPI: &OpenAPIConfig{Title: "typed"}}, func(r Router) {
+		r.LoadControllers([]Controller{&typedController{NewController("/typed", nil, nil)}})
+	}, req)
+}
+
+func TestHandle_BindsAllSources(t *testing.T) {
+	rr := serveTyped(t, http.MethodPut, "/typed/abc?notify=true", `{"title":"hello","id":"zzz"}`,
+		map[string]string{"X-Tenant-Id": "t1"})
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{
+		"code": "10000", "status": 200, "message": "updated",
+		"data": {"id": "abc", "title": "hello", "notify": true, "tenant": "t1"}
+	}`, rr.Body.String())
+}
+
+func TestHandle_SourcesOnlyBindTaggedFields(t *testing.T) {
+	rr := serveTyped(t, http.MethodPost, "/typed/roles/admin?Role=admin&Name=admin&Tenant=t2",
+		`{"role":"user","Name":"ann","Tenant":"t3"}`, map[string]string{"Role": "admin", "Name": "admin"})
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res struct {
+		Data map[string]any `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, map[string]any{"role": "user", "Name": "ann", "Tenant": "", "Page": 1.0}, res.Data)
+}
+
+func TestHandle_ValidationErrors(t *testing.T) {
+	rr := serveTyped(t, http.MethodPut, "/typed/abcd", `{"title":"x"}`, nil)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var res struct {
+		Errors []FieldError `json:"errors"`
+	}
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	fields := []string{}
+	for _, e := range res.Errors {
+		fields = append(fields, e.Field)
+	}
+	assert.ElementsMatch(t, []string{"id", "Tenant", "title"}, fields)
+}
+
+func TestHandle_InvalidBody(t *testing.T) {
+	rr := serveTyped(t, http.MethodPost, "/typed/", `{`, nil)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHandle_StatusAndMiddleware(t *testing.T) {
+	rr := serveTyped(t, http.MethodPost, "/typed/", `{"field":"value"}`, nil)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"data":{"field":"value"}`)
+
+	rr = serveTyped(t, http.MethodPost, "/typed/", `{"field":"value"}`, map[string]string{"X-Block": "1"})
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestHandle_Errors(t *testing.T) {
+	rr := serveTyped(t, http.MethodDelete, "/typed/ok", "", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"code":"10000","status":200,"message":"deleted"}`, rr.Body.String())
+
+	rr = serveTyped(t, http.MethodDelete, "/typed/missing", "", nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), "blog not found")
+
+	rr = serveTyped(t, http.MethodDelete, "/typed/broken", "", nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestHandle_OpenAPI(t *testing.T) {
+	rr := serveTyped(t, http.MethodGet, "/openapi.json", "", nil)
+
+	var doc openapi.Document
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+
+	update := (*doc.Paths["/typed/{id}"])["put"]
+	assert.Equal(t, "update", update.Summary)
+	var params []string
+	for _, p := range update.Parameters {
+		params = append(params, p.In+":"+p.Name)
+	}
+	assert.Equal(t, []string{"path:id", "query:notify", "header:X-Tenant-Id"}, params)
+	body := doc.Components.Schemas["updateReq"]
+	assert.ElementsMatch(t, []string{"title", "content"}, keys(body.Properties))
+	assert.Equal(t, "#/components/schemas/updateRes", update.Responses["200"].Content["application/json"].Schema.Properties["data"].Ref)
+
+	create := (*doc.Paths["/typed/"])["post"]
+	assert.Contains(t, create.Responses, "201")
+
+	del := (*doc.Paths["/typed/{id}"])["delete"]
+	assert.Nil(t, del.RequestBody)
+	assert.NotContains(t, del.Responses["200"].Content["application/json"].Schema.Properties, "data")
+}
+
+func keys[V any](m map[string]V) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
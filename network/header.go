@@ -3,4 +3,5 @@ package network
 const (
 	ApiKeyHeader        = "x-api-key"
 	AuthorizationHeader = "Authorization"
+	RequestIdHeader     = "X-Request-Id"
 )
@@ -1,6 +1,8 @@
 package network
 
 import (
+	"context"
+
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
@@ -19,6 +21,17 @@ type Response[T any] interface {
 	GetData() *T
 }
 
+// resCoder is implemented by ApiErrors that carry their own ResCode;
+// others are sent with FailureCode.
+type resCoder interface {
+	GetResCode() ResCode
+}
+
+// fieldErrorer is implemented by responses listing failed fields.
+type fieldErrorer interface {
+	GetErrors() []FieldError
+}
+
 type Controller interface {
 	Path() string
 	Authentication() gin.HandlerFunc
@@ -63,11 +76,20 @@ type ParamNMiddlewareProvider[T any] interface {
 type AuthenticationProvider Param0MiddlewareProvider
 type AuthorizationProvider ParamNMiddlewareProvider[string]
 
+type LifecycleHook func(ctx context.Context) error
+
 type BaseRouter interface {
 	GetEngine() *gin.Engine
 	RegisterValidationParsers(tagNameFunc validator.TagNameFunc)
+	RegisterValidation(tag string, fn validator.Func) error
+	RegisterStructValidation(fn validator.StructLevelFunc, types ...any)
+	RegisterAlias(alias string, tags string)
 	LoadRootMiddlewares(middlewares []RootMiddleware)
+	OnStart(hooks ...LifecycleHook)
+	OnShutdown(hooks ...LifecycleHook)
 	Start(ip string, port uint16)
+	StartGracefully(ip string, port uint16) error
+	Serve(ctx context.Context, ip string, port uint16) error
 }
 
 type Router interface {
@@ -6,9 +6,9 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/afteracademy/goserve/v2/logger"
 	"github.com/afteracademy/goserve/v2/utility"
 	"github.com/gin-gonic/gin"
-	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/mock"
 )
@@ -60,9 +60,7 @@ func MockTestHandler(
 	ctx, r := gin.CreateTestContext(rr)
 	r.Handle(httpMethod, path, handler)
 
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterTagNameFunc(CustomTagNameFunc())
-	}
+	Validator()
 
 	req, err := http.NewRequest(httpMethod, url, bytes.NewBuffer([]byte(body)))
 	if err != nil {
@@ -81,6 +79,31 @@ func MockTestHandler(
 	return rr
 }
 
+// MockTestRouter serves req on a router built from config, for tests of
+// the router wide settings. mount registers the routes; Mode and Logger
+// default to gin.TestMode and logger.Nop().
+func MockTestRouter(
+	t *testing.T,
+	config RouterConfig,
+	mount func(r Router),
+	req *http.Request,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	if config.Mode == "" {
+		config.Mode = gin.TestMode
+	}
+	if config.Logger == nil {
+		config.Logger = logger.Nop()
+	}
+
+	r := NewRouterWithConfig(config)
+	mount(r)
+
+	rr := httptest.NewRecorder()
+	r.GetEngine().ServeHTTP(rr, req)
+	return rr
+}
+
 func MockTestRootMiddleware(
 	t *testing.T,
 	middleware RootMiddleware,
@@ -94,9 +117,7 @@ func MockTestRootMiddleware(
 	middleware.Attach(r)
 	r.GET("/", handler)
 
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterTagNameFunc(CustomTagNameFunc())
-	}
+	Validator()
 
 	req, err := http.NewRequest("GET", "/", nil)
 	if err != nil {
@@ -128,9 +149,7 @@ func MockTestRootMiddlewareWithUrl(
 	middleware.Attach(r)
 	r.GET(path, handler)
 
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterTagNameFunc(CustomTagNameFunc())
-	}
+	Validator()
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -162,9 +181,7 @@ func MockTestAuthenticationProvider(
 	r.Use(auth.Middleware())
 	r.GET("/", handler)
 
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterTagNameFunc(CustomTagNameFunc())
-	}
+	Validator()
 
 	req, err := http.NewRequest("GET", "/", nil)
 	if err != nil {
@@ -203,9 +220,7 @@ func MockTestAuthorizationProvider(
 	}
 	r.GET("/", handler)
 
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterTagNameFunc(CustomTagNameFunc())
-	}
+	Validator()
 
 	req, err := http.NewRequest("GET", "/", nil)
 	if err != nil {
@@ -235,9 +250,7 @@ func MockTestController(
 
 	controller.MountRoutes(r.Group(controller.Path()))
 
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterTagNameFunc(CustomTagNameFunc())
-	}
+	Validator()
 
 	req, err := http.NewRequest(httpMethod, url, bytes.NewBuffer([]byte(body)))
 	if err != nil {
@@ -0,0 +1,148 @@
+package network
+
+import (
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// ReqMultipart binds a multipart/form-data request by form tags, files
+// into *multipart.FileHeader or []*multipart.FileHeader fields, and
+// validates it with ValidateDto. Uploads are checked with the filesize,
+// maxfiles and mimetype tags:
+//
+//	type Upload struct {
+//		Title  string                  `form:"title" validate:"required"`
+//		Avatar *multipart.FileHeader   `form:"avatar" validate:"required,filesize=2MB,mimetype=image/png image/jpeg"`
+//		Files  []*multipart.FileHeader `form:"files" validate:"maxfiles=5,filesize=10MB,mimetype=application/pdf image/*"`
+//	}
+func ReqMultipart[T any](ctx *gin.Context) (*T, error) {
+	var payload T
+	if err := ctx.ShouldBindWith(&payload, binding.FormMultipart); err != nil {
+		e := processErrors(&payload, err)
+		return &payload, e
+	}
+
+	return ValidateDto(&payload)
+}
+
+func registerFileValidations(v *validator.Validate) {
+	v.RegisterValidation("filesize", validateFileSize)
+	v.RegisterValidation("maxfiles", validateMaxFiles)
+	v.RegisterValidation("mimetype", validateMimeType)
+}
+
+var fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+
+// fileHeaders returns the uploads of a file field, nil for other fields
+func fileHeaders(field reflect.Value) []*multipart.FileHeader {
+	switch {
+	case field.Type() == fileHeaderType && field.CanAddr():
+		return []*multipart.FileHeader{field.Addr().Interface().(*multipart.FileHeader)}
+	case field.Kind() == reflect.Pointer && field.Type().Elem() == fileHeaderType:
+		if field.IsNil() {
+			return nil
+		}
+		return []*multipart.FileHeader{field.Interface().(*multipart.FileHeader)}
+	case field.Kind() == reflect.Slice:
+		files, _ := field.Interface().([]*multipart.FileHeader)
+		return files
+	}
+	return nil
+}
+
+// ParseFileSize reads sizes like 512, 100KB, 5MB or 1GB in bytes, with
+// 1KB being 1024 bytes.
+func ParseFileSize(size string) (int64, error) {
+	units := []struct {
+		suffix     string
+		multiplier int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}}
+
+	s := strings.ToUpper(strings.TrimSpace(size))
+	multiplier := int64(1)
+	for _, unit := range units {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid file size %q", size)
+	}
+	return n * multiplier, nil
+}
+
+func validateFileSize(fl validator.FieldLevel) bool {
+	limit, err := ParseFileSize(fl.Param())
+	if err != nil {
+		panic(err)
+	}
+	for _, file := range fileHeaders(fl.Field()) {
+		if file.Size > limit {
+			return false
+		}
+	}
+	return true
+}
+
+func validateMaxFiles(fl validator.FieldLevel) bool {
+	limit, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		panic(fmt.Errorf("invalid file count %q", fl.Param()))
+	}
+	return len(fileHeaders(fl.Field())) <= limit
+}
+
+// validateMimeType sniffs the content instead of trusting the type sent
+// by the client. Params are space separated and may end in /* to allow
+// any subtype.
+func validateMimeType(fl validator.FieldLevel) bool {
+	allowed := strings.Fields(fl.Param())
+	for _, file := range fileHeaders(fl.Field()) {
+		mimeType, err := SniffMimeType(file)
+		if err != nil || !matchMimeType(mimeType, allowed) {
+			return false
+		}
+	}
+	return true
+}
+
+// SniffMimeType detects the media type of an upload from its first 512
+// bytes, without params like charset.
+func SniffMimeType(file *multipart.FileHeader) (string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := f.Read(head)
+	if err != nil && n == 0 && file.Size > 0 {
+		return "", err
+	}
+	mimeType, _, _ := strings.Cut(http.DetectContentType(head[:n]), ";")
+	return strings.TrimSpace(mimeType), nil
+}
+
+func matchMimeType(mimeType string, allowed []string) bool {
+	for _, a := range allowed {
+		if a == mimeType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(a, "/*"); ok && strings.HasPrefix(mimeType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package network
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n0000")
+
+type uploadPayload struct {
+	Title  string                  `form:"title" validate:"required"`
+	Avatar *multipart.FileHeader   `form:"avatar" validate:"required,filesize=1KB,mimetype=image/png image/jpeg"`
+	Files  []*multipart.FileHeader `form:"files" validate:"maxfiles=2,filesize=1KB,mimetype=text/*"`
+}
+
+type upload struct {
+	field, name string
+	data        []byte
+}
+
+func multipartRequest(t *testing.T, fields map[string]string, uploads ...upload) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for k, v := range fields {
+		assert.NoError(t, w.WriteField(k, v))
+	}
+	for _, u := range uploads {
+		part, err := w.CreateFormFile(u.field, u.name)
+		assert.NoError(t, err)
+		part.Write(u.data)
+	}
+	assert.NoError(t, w.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func bindUpload(req *http.Request) (*uploadPayload, error) {
+	rr := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rr)
+	ctx.Request = req
+	return ReqMultipart[uploadPayload](ctx)
+}
+
+func TestReqMultipart(t *testing.T) {
+	payload, err := bindUpload(multipartRequest(t, map[string]string{"title": "docs"},
+		upload{"avatar", "me.png", pngHeader},
+		upload{"files", "a.txt", []byte("hello")},
+		upload{"files", "b.txt", []byte("world")},
+	))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "docs", payload.Title)
+	assert.Equal(t, "me.png", payload.Avatar.Filename)
+	assert.Len(t, payload.Files, 2)
+}
+
+func TestReqMultipart_FileErrors(t *testing.T) {
+	_, err := bindUpload(multipartRequest(t, nil,
+		upload{"avatar", "me.png", []byte("not an image")},
+		upload{"files", "a.txt", bytes.Repeat([]byte("a"), 2048)},
+		upload{"files", "b.txt", []byte("b")},
+		upload{"files", "c.txt", []byte("c")},
+	))
+
+	var validationError *ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	var tags []string
+	for _, e := range validationError.Errors {
+		tags = append(tags, e.Field+":"+e.Tag)
+	}
+	assert.ElementsMatch(t, []string{"title:required", "avatar:mimetype", "files:maxfiles"}, tags)
+	assert.Contains(t, validationError.Error(), "avatar must be of type image/png image/jpeg")
+}
+
+func TestReqMultipart_FileSize(t *testing.T) {
+	_, err := bindUpload(multipartRequest(t, map[string]string{"title": "docs"},
+		upload{"avatar", "me.png", append(pngHeader, bytes.Repeat([]byte("0"), 1024)...)},
+	))
+
+	var validationError *ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "filesize", validationError.Errors[0].Tag)
+	assert.Equal(t, "avatar must not be larger than 1KB", validationError.Errors[0].Message)
+}
+
+func TestParseFileSize(t *testing.T) {
+	for input, want := range map[string]int64{"512": 512, "2kb": 2048, "5MB": 5 << 20, "1 GB": 1 << 30, "10B": 10} {
+		got, err := ParseFileSize(input)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, input)
+	}
+	_, err := ParseFileSize("big")
+	assert.Error(t, err)
+}
+
+func TestMatchMimeType(t *testing.T) {
+	assert.True(t, matchMimeType("image/png", []string{"image/*"}))
+	assert.True(t, matchMimeType("application/pdf", []string{"image/png", "application/pdf"}))
+	assert.False(t, matchMimeType("imagex/png", []string{"image/*"}))
+}
@@ -0,0 +1,380 @@
+package network
+
+import (
+	"html"
+	"maps"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/afteracademy/goserve/v2/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSpecPath = "/openapi.json"
+	defaultDocsPath = "/docs"
+)
+
+// RouteDoc describes a route for the generated OpenAPI document. The
+// inputs and Response are sample values, usually zero values, of the types
+// the handler uses with ReqBody, ReqQuery, ReqParams, ReqHeaders and
+// SendSuccessDataResponse.
+type RouteDoc struct {
+	Summary     string
+	Description string
+	// Tags default to the first segment of the route path.
+	Tags        []string
+	OperationId string
+	Deprecated  bool
+	// Auth marks routes behind the controller Authentication and Roles the
+	// roles their Authorization allows.
+	Auth    bool
+	Roles   []string
+	Body    any
+	Query   any
+	Params  any
+	Headers any
+	// Response is the data of the success response, nil for a message only.
+	Response any
+	// Status is the success status, 200 by default.
+	Status int
+}
+
+type OpenAPIConfig struct {
+	Title       string
+	Version     string
+	Description string
+	Servers     []string
+	// SpecPath serves the JSON document, "/openapi.json" by default.
+	SpecPath string
+	// DocsPath serves a Swagger UI page of the document, "/docs" by default.
+	DocsPath string
+	// SwaggerUI replaces the Swagger UI files the docs page loads.
+	SwaggerUI *SwaggerUIAssets
+	// SecuritySchemes replace the default bearerAuth and apiKeyAuth schemes.
+	// Routes documented with Auth accept any of them.
+	SecuritySchemes map[string]*openapi.SecurityScheme
+}
+
+// SwaggerUIAssets are the Swagger UI files of the docs page. The browser
+// checks them against the SRI hashes when set, e.g. "sha384-...".
+type SwaggerUIAssets struct {
+	CSS          string
+	CSSIntegrity string
+	JS           string
+	JSIntegrity  string
+}
+
+const swaggerUIVersion = "5.17.14"
+
+// DefaultSwaggerUIAssets loads a pinned swagger-ui-dist release from unpkg.
+func DefaultSwaggerUIAssets() *SwaggerUIAssets {
+	base := "https://unpkg.com/swagger-ui-dist@" + swaggerUIVersion
+	return &SwaggerUIAssets{
+		CSS: base + "/swagger-ui.css",
+		JS:  base + "/swagger-ui-bundle.js",
+	}
+}
+
+// routeRegistry keeps the docs of the routes a router mounts, by method
+// and full path.
+type routeRegistry struct {
+	mu   sync.RWMutex
+	docs map[string]RouteDoc
+}
+
+func newRouteRegistry() *routeRegistry {
+	return &routeRegistry{docs: make(map[string]RouteDoc)}
+}
+
+func (rr *routeRegistry) add(method string, path string, doc RouteDoc) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	rr.docs[method+" "+path] = doc
+}
+
+func (rr *routeRegistry) snapshot() map[string]RouteDoc {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	return maps.Clone(rr.docs)
+}
+
+// mounting is the registry of the router running MountRoutes, which
+// Describe records to. Mounts are serialized so each router only gets the
+// routes of its own controllers.
+var mounting struct {
+	sync.Mutex
+	registry atomic.Pointer[routeRegistry]
+}
+
+func (rr *routeRegistry) mount(fn func()) {
+	mounting.Lock()
+	defer mounting.Unlock()
+	mounting.registry.Store(rr)
+	defer mounting.registry.Store(nil)
+	fn()
+}
+
+// Describe registers handlers for the route like group.Handle and, within
+// MountRoutes, records its docs with the router loading the controller:
+//
+//	network.Describe(group, http.MethodPost, "/", network.RouteDoc{
+//		Summary: "create blog", Auth: true, Roles: []string{"admin"},
+//		Body: dto.CreateBlog{}, Response: dto.Blog{}, Status: http.StatusCreated,
+//	}, c.Authentication(), c.Authorization("admin"), c.create)
+func Describe(group *gin.RouterGroup, method string, path string, doc RouteDoc, handlers ...gin.HandlerFunc) gin.IRoutes {
+	routes := group.Handle(method, path, handlers...)
+	if rr := mounting.registry.Load(); rr != nil {
+		rr.add(method, joinPaths(group.BasePath(), path), doc)
+	}
+	return routes
+}
+
+// joinPaths mirrors how gin joins group and route paths
+func joinPaths(base string, relative string) string {
+	if relative == "" {
+		return base
+	}
+	joined := path.Join(base, relative)
+	if strings.HasSuffix(relative, "/") && !strings.HasSuffix(joined, "/") {
+		return joined + "/"
+	}
+	return joined
+}
+
+// NewOpenAPIDocument builds the document of routes, adding the details of
+// the ones in docs, keyed by method and path like "GET /blogs/:id". Other
+// routes are listed with their path params only.
+func NewOpenAPIDocument(config OpenAPIConfig, routes gin.RoutesInfo, docs map[string]RouteDoc) *openapi.Document {
+	schemas := openapi.NewSchemas()
+	securitySchemes := config.SecuritySchemes
+	if securitySchemes == nil {
+		securitySchemes = map[string]*openapi.SecurityScheme{
+			"bearerAuth": openapi.BearerScheme(),
+			"apiKeyAuth": openapi.ApiKeyScheme(ApiKeyHeader),
+		}
+	}
+	var security []openapi.SecurityRequirement
+	for _, name := range sortedKeys(securitySchemes) {
+		security = append(security, openapi.SecurityRequirement{name: {}})
+	}
+
+	doc := &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info: openapi.Info{
+			Title:       config.Title,
+			Version:     config.Version,
+			Description: config.Description,
+		},
+		Paths: make(map[string]*openapi.PathItem),
+		Components: openapi.Components{
+			SecuritySchemes: securitySchemes,
+		},
+	}
+	for _, url := range config.Servers {
+		doc.Servers = append(doc.Servers, openapi.Server{Url: url})
+	}
+
+	errorSchema := &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"code":    {Type: "string"},
+			"status":  {Type: "integer"},
+			"message": {Type: "string"},
+			"errors":  {Type: "array", Items: schemas.Schema(reflect.TypeOf(FieldError{}))},
+		},
+		Required: []string{"code", "status", "message"},
+	}
+	schemas.Components()["ErrorResponse"] = errorSchema
+	errorRef := &openapi.Schema{Ref: "#/components/schemas/ErrorResponse"}
+
+	tags := make(map[string]struct{})
+	for _, route := range routes {
+		if route.Path == config.SpecPath || route.Path == config.DocsPath {
+			continue
+		}
+		rd := docs[route.Method+" "+route.Path]
+		op := newOperation(schemas, route.Path, rd, errorRef)
+		if rd.Auth {
+			op.Security = security
+		}
+		for _, tag := range op.Tags {
+			tags[tag] = struct{}{}
+		}
+
+		path := openAPIPath(route.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &openapi.PathItem{}
+			doc.Paths[path] = item
+		}
+		(*item)[strings.ToLower(route.Method)] = op
+	}
+
+	for _, tag := range sortedKeys(tags) {
+		doc.Tags = append(doc.Tags, openapi.Tag{Name: tag})
+	}
+	doc.Components.Schemas = schemas.Components()
+	return doc
+}
+
+func newOperation(schemas *openapi.Schemas, path string, rd RouteDoc, errorRef *openapi.Schema) *openapi.Operation {
+	op := &openapi.Operation{
+		OperationId: rd.OperationId,
+		Summary:     rd.Summary,
+		Description: rd.Description,
+		Tags:        rd.Tags,
+		Deprecated:  rd.Deprecated,
+		Roles:       rd.Roles,
+		Responses:   make(map[string]*openapi.Response),
+	}
+	if len(op.Tags) == 0 {
+		if segment := strings.Split(strings.TrimPrefix(path, "/"), "/")[0]; segment != "" && !strings.ContainsAny(segment, ":*") {
+			op.Tags = []string{segment}
+		}
+	}
+
+	if rd.Params != nil {
+		op.Parameters = append(op.Parameters, schemas.Parameters(reflect.TypeOf(rd.Params), openapi.InPath)...)
+	}
+	// path params the Params type does not cover are still documented
+	for _, segment := range strings.Split(path, "/") {
+		if len(segment) < 2 || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		if !hasParameter(op.Parameters, segment[1:]) {
+			op.Parameters = append(op.Parameters, &openapi.Parameter{
+				Name: segment[1:], In: openapi.InPath, Required: true, Schema: &openapi.Schema{Type: "string"},
+			})
+		}
+	}
+	if rd.Query != nil {
+		op.Parameters = append(op.Parameters, schemas.Parameters(reflect.TypeOf(rd.Query), openapi.InQuery)...)
+	}
+	if rd.Headers != nil {
+		op.Parameters = append(op.Parameters, schemas.Parameters(reflect.TypeOf(rd.Headers), openapi.InHeader)...)
+	}
+	if rd.Body != nil {
+		op.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content:  jsonContent(schemas.Schema(reflect.TypeOf(rd.Body))),
+		}
+	}
+
+	status := rd.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"code":    {Type: "string"},
+			"status":  {Type: "integer"},
+			"message": {Type: "string"},
+		},
+		Required: []string{"code", "status", "message"},
+	}
+	if rd.Response != nil {
+		success.Properties["data"] = schemas.Schema(reflect.TypeOf(rd.Response))
+	}
+	op.Responses[strconv.Itoa(status)] = &openapi.Response{Description: http.StatusText(status), Content: jsonContent(success)}
+
+	errorResponse := func(status int) {
+		op.Responses[strconv.Itoa(status)] = &openapi.Response{Description: http.StatusText(status), Content: jsonContent(errorRef)}
+	}
+	if rd.Body != nil || rd.Query != nil || rd.Params != nil || rd.Headers != nil {
+		errorResponse(http.StatusBadRequest)
+	}
+	if rd.Auth {
+		errorResponse(http.StatusUnauthorized)
+	}
+	if len(rd.Roles) > 0 {
+		errorResponse(http.StatusForbidden)
+	}
+	return op
+}
+
+func hasParameter(params []*openapi.Parameter, name string) bool {
+	for _, p := range params {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonContent(schema *openapi.Schema) map[string]*openapi.MediaType {
+	return map[string]*openapi.MediaType{"application/json": {Schema: schema}}
+}
+
+// openAPIPath turns gin params like /blogs/:id into /blogs/{id}
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if len(segment) > 1 && (segment[0] == ':' || segment[0] == '*') {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func openAPIHandlers(eng *gin.Engine, config OpenAPIConfig, routes *routeRegistry) {
+	eng.GET(config.SpecPath, func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, NewOpenAPIDocument(config, eng.Routes(), routes.snapshot()))
+	})
+	assets := config.SwaggerUI
+	if assets == nil {
+		assets = DefaultSwaggerUIAssets()
+	}
+	page := strings.NewReplacer(
+		"{{title}}", html.EscapeString(config.Title),
+		"{{spec}}", html.EscapeString(config.SpecPath),
+		"{{css}}", assetAttrs(assets.CSS, assets.CSSIntegrity),
+		"{{js}}", assetAttrs(assets.JS, assets.JSIntegrity),
+	).Replace(docsPage)
+	eng.GET(config.DocsPath, func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(page))
+	})
+}
+
+// assetAttrs are the src or href value of an asset and its integrity
+func assetAttrs(url string, integrity string) string {
+	attrs := `"` + html.EscapeString(url) + `"`
+	if integrity != "" {
+		attrs += ` integrity="` + html.EscapeString(integrity) + `"`
+	}
+	return attrs + ` crossorigin="anonymous"`
+}
+
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{title}}</title>
+  <link rel="stylesheet" href={{css}}>
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src={{js}}></script>
+  <script>
+    window.onload = () => { window.ui = SwaggerUIBundle({ url: "{{spec}}", dom_id: "#swagger-ui" }); };
+  </script>
+</body>
+</html>
+`
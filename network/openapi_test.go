@@ -0,0 +1,205 @@
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/afteracademy/goserve/v2/openapi"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type docParams struct {
+	Id string `uri:"id" validate:"required,len=24"`
+}
+
+type docQuery struct {
+	Limit int `form:"limit" validate:"max=50"`
+}
+
+type docController struct {
+	Controller
+}
+
+func (c *docController) MountRoutes(group *gin.RouterGroup) {
+	Describe(group, http.MethodPost, "/", RouteDoc{
+		Summary: "create", Auth: true, Roles: []string{"admin"},
+		Body: MockPayload{}, Response: MockPayload{}, Status: http.StatusCreated,
+	}, c.Authentication(), c.Authorization("admin"), MockSuccessMsgHandler("created"))
+	Describe(group, http.MethodGet, "/:id", RouteDoc{Params: docParams{}, Query: docQuery{}},
+		MockSuccessMsgHandler("found"))
+	group.DELETE("/:id/*path", MockSuccessMsgHandler("deleted"))
+
+	private := group.Group("/private", c.Authentication())
+	Describe(private, http.MethodGet, "/", RouteDoc{Summary: "private", Auth: true}, MockSuccessMsgHandler("private"))
+
+	GET(group, "/mine/list", func(ctx *gin.Context, req *struct{}) (*struct{}, error) {
+		return nil, nil
+	}, Authenticated(c), Authorized(c, "author"))
+}
+
+func newDocController() Controller {
+	pass := gin.HandlerFunc(func(ctx *gin.Context) { ctx.Next() })
+	return newDocControllerWithAuth(pass)
+}
+
+func newDocControllerWithAuth(handler gin.HandlerFunc) Controller {
+	auth := new(MockAuthenticationProvider)
+	auth.On("Middleware").Return(handler)
+	authz := new(MockAuthorizationProvider)
+	authz.On("Middleware", mock.Anything).Return(handler)
+	return &docController{NewController("/blogs", auth, authz)}
+}
+
+type otherController struct {
+	Controller
+}
+
+func (c *otherController) MountRoutes(group *gin.RouterGroup) {
+	Describe(group, http.MethodGet, "/", RouteDoc{Summary: "other"}, MockSuccessMsgHandler("other"))
+}
+
+func serveOpenAPI(t *testing.T, url string) *httptest.ResponseRecorder {
+	t.Helper()
+	config := RouterConfig{
+		OpenAPI: &OpenAPIConfig{Title: "Blog API", Version: "1.0.0", Servers: []string{"https://api.example.com"}},
+	}
+	return MockTestRouter(t, config, func(r Router) {
+		r.LoadControllers([]Controller{newDocController()})
+	}, httptest.NewRequest(http.MethodGet, url, nil))
+}
+
+func openAPIDocument(t *testing.T, rr *httptest.ResponseRecorder) *openapi.Document {
+	t.Helper()
+	var doc openapi.Document
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+	return &doc
+}
+
+func TestOpenAPI_Document(t *testing.T) {
+	rr := serveOpenAPI(t, "/openapi.json")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var doc openapi.Document
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+	assert.Equal(t, "3.1.0", doc.OpenAPI)
+	assert.Equal(t, "Blog API", doc.Info.Title)
+	assert.Equal(t, "https://api.example.com", doc.Servers[0].Url)
+	assert.NotContains(t, doc.Paths, "/openapi.json")
+	assert.NotContains(t, doc.Paths, "/docs")
+	assert.Equal(t, []openapi.Tag{{Name: "blogs"}}, doc.Tags)
+
+	create := (*doc.Paths["/blogs/"])["post"]
+	assert.Equal(t, "create", create.Summary)
+	assert.Equal(t, []string{"admin"}, create.Roles)
+	assert.Len(t, create.Security, 2)
+	assert.Equal(t, "#/components/schemas/MockPayload", create.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Contains(t, create.Responses, "201")
+	assert.Contains(t, create.Responses, "400")
+	assert.Contains(t, create.Responses, "401")
+	assert.Contains(t, create.Responses, "403")
+	data := create.Responses["201"].Content["application/json"].Schema.Properties["data"]
+	assert.Equal(t, "#/components/schemas/MockPayload", data.Ref)
+
+	payload := doc.Components.Schemas["MockPayload"]
+	assert.Equal(t, []string{"field"}, payload.Required)
+	assert.Equal(t, int64(2), *payload.Properties["field"].MinLength)
+	assert.Contains(t, doc.Components.Schemas, "ErrorResponse")
+	assert.Contains(t, doc.Components.SecuritySchemes, "bearerAuth")
+
+	get := (*doc.Paths["/blogs/{id}"])["get"]
+	assert.Empty(t, get.Security)
+	assert.Len(t, get.Parameters, 2)
+	assert.Equal(t, openapi.InPath, get.Parameters[0].In)
+	assert.Equal(t, int64(24), *get.Parameters[0].Schema.MaxLength)
+	assert.Equal(t, "limit", get.Parameters[1].Name)
+	assert.Equal(t, 50.0, *get.Parameters[1].Schema.Maximum)
+
+	del := (*doc.Paths["/blogs/{id}/{path}"])["delete"]
+	assert.Len(t, del.Parameters, 2)
+	assert.Contains(t, del.Responses, "200")
+
+	private := (*doc.Paths["/blogs/private/"])["get"]
+	assert.Len(t, private.Security, 2)
+	assert.Empty(t, private.Roles)
+	assert.Contains(t, private.Responses, "401")
+	assert.NotContains(t, private.Responses, "403")
+
+	mine := (*doc.Paths["/blogs/mine/list"])["get"]
+	assert.Len(t, mine.Security, 2)
+	assert.Equal(t, []string{"author"}, mine.Roles)
+	assert.Contains(t, mine.Responses, "403")
+}
+
+func TestOpenAPI_DescribedRoutesServe(t *testing.T) {
+	rr := MockTestRouter(t, RouterConfig{}, func(r Router) {
+		r.LoadControllers([]Controller{newDocController()})
+	}, httptest.NewRequest(http.MethodPost, "/blogs/", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"message":"created"`)
+}
+
+func TestOpenAPI_RoutersAreIsolated(t *testing.T) {
+	other := RouterConfig{OpenAPI: &OpenAPIConfig{Title: "Other"}}
+	rr := MockTestRouter(t, other, func(r Router) {
+		r.LoadControllers([]Controller{&otherController{NewController("/other", nil, nil)}})
+	}, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	doc := openAPIDocument(t, rr)
+	assert.Equal(t, "other", (*doc.Paths["/other/"])["get"].Summary)
+	assert.NotContains(t, doc.Paths, "/blogs/")
+
+	doc = openAPIDocument(t, serveOpenAPI(t, "/openapi.json"))
+	assert.NotContains(t, doc.Paths, "/other/")
+}
+
+func TestOpenAPI_AuthMiddlewareUnchanged(t *testing.T) {
+	deny := gin.HandlerFunc(func(ctx *gin.Context) {
+		SendUnauthorizedError(ctx, "denied", nil)
+	})
+	rr := MockTestRouter(t, RouterConfig{}, func(r Router) {
+		r.GetEngine().Use(func(ctx *gin.Context) {
+			ctx.Set("routeProbe", "set by another middleware")
+			ctx.Next()
+		})
+		r.LoadControllers([]Controller{newDocControllerWithAuth(deny)})
+	}, httptest.NewRequest(http.MethodPost, "/blogs/", nil))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "created")
+}
+
+func TestOpenAPI_ConfigNotModified(t *testing.T) {
+	config := &OpenAPIConfig{Title: "Blog API"}
+	NewRouterWithConfig(RouterConfig{Mode: gin.TestMode, OpenAPI: config})
+	assert.Empty(t, config.SpecPath)
+	assert.Empty(t, config.DocsPath)
+}
+
+func TestOpenAPI_DocsPage(t *testing.T) {
+	rr := serveOpenAPI(t, "/docs")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rr.Body.String(), `url: "/openapi.json"`)
+	assert.Contains(t, rr.Body.String(), "<title>Blog API</title>")
+	assert.Contains(t, rr.Body.String(), `href="https://unpkg.com/swagger-ui-dist@`+swaggerUIVersion+`/swagger-ui.css"`)
+	assert.Contains(t, rr.Body.String(), `src="https://unpkg.com/swagger-ui-dist@`+swaggerUIVersion+`/swagger-ui-bundle.js"`)
+}
+
+func TestOpenAPI_DocsPageIntegrity(t *testing.T) {
+	config := RouterConfig{OpenAPI: &OpenAPIConfig{SwaggerUI: &SwaggerUIAssets{
+		CSS: "/assets/ui.css", CSSIntegrity: "sha384-css",
+		JS: "/assets/ui.js", JSIntegrity: "sha384-js",
+	}}}
+	rr := MockTestRouter(t, config, func(r Router) {}, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Contains(t, rr.Body.String(), `href="/assets/ui.css" integrity="sha384-css" crossorigin="anonymous"`)
+	assert.Contains(t, rr.Body.String(), `src="/assets/ui.js" integrity="sha384-js" crossorigin="anonymous"`)
+}
+
+func TestJoinPaths(t *testing.T) {
+	assert.Equal(t, "/blogs/", joinPaths("/blogs", "/"))
+	assert.Equal(t, "/blogs/:id", joinPaths("/blogs/", "/:id"))
+	assert.Equal(t, "/blogs", joinPaths("/blogs", ""))
+	assert.Equal(t, "/", joinPaths("/", "/"))
+}
@@ -0,0 +1,101 @@
+package network
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const LinkHeader = "Link"
+
+type Paginated[T any] struct {
+	Items      []*T  `json:"items"`
+	Page       int64 `json:"page"`
+	Limit      int64 `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int64 `json:"totalPages"`
+	HasNext    bool  `json:"hasNext"`
+	HasPrev    bool  `json:"hasPrev"`
+}
+
+func NewPaginated[T any](items []*T, page int64, limit int64, total int64) *Paginated[T] {
+	if items == nil {
+		items = []*T{}
+	}
+	var pages int64
+	if limit > 0 {
+		pages = (total + limit - 1) / limit
+	}
+	return &Paginated[T]{
+		Items:      items,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: pages,
+		HasNext:    page < pages,
+		HasPrev:    page > 1,
+	}
+}
+
+// SendPaginatedResponse sends a page of items with its metadata and sets
+// RFC 5988 Link headers built from the request url and its "page" param.
+func SendPaginatedResponse[T any](ctx *gin.Context, message string, items []*T, page int64, limit int64, total int64) {
+	data := NewPaginated(items, page, limit, total)
+	if link := paginationLinks(ctx.Request.URL, data); link != "" {
+		ctx.Header(LinkHeader, link)
+	}
+	SendSuccessDataResponse(ctx, message, data)
+}
+
+func paginationLinks[T any](u *url.URL, p *Paginated[T]) string {
+	if p.TotalPages == 0 {
+		return ""
+	}
+
+	link := func(page int64, rel string) string {
+		q := u.Query()
+		q.Set("page", strconv.FormatInt(page, 10))
+		q.Set("limit", strconv.FormatInt(p.Limit, 10))
+		target := url.URL{Path: u.Path, RawQuery: q.Encode()}
+		return fmt.Sprintf(`<%s>; rel="%s"`, target.String(), rel)
+	}
+
+	links := []string{link(1, "first")}
+	if p.HasPrev {
+		links = append(links, link(min(p.Page-1, p.TotalPages), "prev"))
+	}
+	if p.HasNext {
+		links = append(links, link(p.Page+1, "next"))
+	}
+	links = append(links, link(p.TotalPages, "last"))
+	return strings.Join(links, ", ")
+}
+
+type CursorPage[T any] struct {
+	Items      []*T   `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	HasNext    bool   `json:"hasNext"`
+}
+
+// SendCursorResponse sends a page of items with the cursor of the next one
+// and a Link header to it; an empty nextCursor marks the last page.
+func SendCursorResponse[T any](ctx *gin.Context, message string, items []*T, nextCursor string) {
+	if items == nil {
+		items = []*T{}
+	}
+	if nextCursor != "" {
+		u := ctx.Request.URL
+		q := u.Query()
+		q.Set("cursor", nextCursor)
+		target := url.URL{Path: u.Path, RawQuery: q.Encode()}
+		ctx.Header(LinkHeader, fmt.Sprintf(`<%s>; rel="next"`, target.String()))
+	}
+	SendSuccessDataResponse(ctx, message, &CursorPage[T]{
+		Items:      items,
+		NextCursor: nextCursor,
+		HasNext:    nextCursor != "",
+	})
+}
@@ -0,0 +1,85 @@
+package network
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPaginated(t *testing.T) {
+	p := NewPaginated[MockPayload](nil, 2, 10, 25)
+	assert.NotNil(t, p.Items)
+	assert.Equal(t, int64(3), p.TotalPages)
+	assert.True(t, p.HasNext)
+	assert.True(t, p.HasPrev)
+
+	p = NewPaginated[MockPayload](nil, 1, 10, 0)
+	assert.Equal(t, int64(0), p.TotalPages)
+	assert.False(t, p.HasNext)
+	assert.False(t, p.HasPrev)
+}
+
+func TestSendPaginatedResponse(t *testing.T) {
+	items := []*MockPayload{{Field: "one"}, {Field: "two"}}
+
+	mockHandler := func(ctx *gin.Context) {
+		SendPaginatedResponse(ctx, "blogs", items, 2, 2, 5)
+	}
+
+	rr := MockTestHandler(t, "GET", "/blogs", "/blogs?page=2&limit=2&tag=go", "", mockHandler, nil)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t,
+		`</blogs?limit=2&page=1&tag=go>; rel="first", `+
+			`</blogs?limit=2&page=1&tag=go>; rel="prev", `+
+			`</blogs?limit=2&page=3&tag=go>; rel="next", `+
+			`</blogs?limit=2&page=3&tag=go>; rel="last"`,
+		rr.Header().Get(LinkHeader))
+	assert.JSONEq(t, `{
+		"code": "10000",
+		"status": 200,
+		"message": "blogs",
+		"data": {
+			"items": [{"field": "one"}, {"field": "two"}],
+			"page": 2,
+			"limit": 2,
+			"total": 5,
+			"totalPages": 3,
+			"hasNext": true,
+			"hasPrev": true
+		}
+	}`, rr.Body.String())
+}
+
+func TestSendPaginatedResponse_Empty(t *testing.T) {
+	mockHandler := func(ctx *gin.Context) {
+		SendPaginatedResponse[MockPayload](ctx, "blogs", nil, 1, 10, 0)
+	}
+
+	rr := MockTestHandler(t, "GET", "/blogs", "/blogs", "", mockHandler, nil)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get(LinkHeader))
+	assert.Contains(t, rr.Body.String(), `"items":[]`)
+}
+
+func TestSendCursorResponse(t *testing.T) {
+	items := []*MockPayload{{Field: "one"}}
+
+	rr := MockTestHandler(t, "GET", "/blogs", "/blogs?limit=1", "", func(ctx *gin.Context) {
+		SendCursorResponse(ctx, "blogs", items, "abc")
+	}, nil)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `</blogs?cursor=abc&limit=1>; rel="next"`, rr.Header().Get(LinkHeader))
+	assert.Contains(t, rr.Body.String(), `"data":{"items":[{"field":"one"}],"nextCursor":"abc","hasNext":true}`)
+
+	rr = MockTestHandler(t, "GET", "/blogs", "/blogs?limit=1", "", func(ctx *gin.Context) {
+		SendCursorResponse(ctx, "blogs", items, "")
+	}, nil)
+
+	assert.Empty(t, rr.Header().Get(LinkHeader))
+	assert.Contains(t, rr.Body.String(), `"data":{"items":[{"field":"one"}],"hasNext":false}`)
+}
@@ -0,0 +1,100 @@
+package network
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ErrorFormat int
+
+const (
+	// ErrorFormatEnvelope sends errors in the {code,status,message} envelope.
+	ErrorFormatEnvelope ErrorFormat = iota
+	// ErrorFormatProblem sends errors as RFC 7807 application/problem+json.
+	// Problem documents have a standard shape, so they are always JSON and
+	// not wrapped by the Envelope or encoded by the Codecs.
+	ErrorFormatProblem
+	// ErrorFormatNegotiate sends problem documents only to clients that
+	// list application/problem+json in Accept, the envelope otherwise, and
+	// sets Vary: Accept on errors as the body depends on it.
+	ErrorFormatNegotiate
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	errorFormatKey     = "errorFormat"
+)
+
+// Problem is an RFC 7807 problem details document. Code, RequestId and
+// Errors are extension members.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      ResCode      `json:"code,omitempty"`
+	RequestId string       `json:"requestId,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+func SetErrorFormat(ctx *gin.Context, format ErrorFormat) {
+	ctx.Set(errorFormatKey, format)
+}
+
+func errorFormatHandler(format ErrorFormat) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		SetErrorFormat(ctx, format)
+		ctx.Next()
+	}
+}
+
+func wantsProblem(ctx *gin.Context) bool {
+	format, _ := ctx.Get(errorFormatKey)
+	switch format {
+	case ErrorFormatProblem:
+		return true
+	case ErrorFormatNegotiate:
+		varyAccept(ctx)
+		return acceptsProblem(ctx.GetHeader("Accept"))
+	default:
+		return false
+	}
+}
+
+func acceptsProblem(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && mediaType == ProblemContentType && params["q"] != "0" {
+			return true
+		}
+	}
+	return false
+}
+
+func newProblem(ctx *gin.Context, res Response[any]) *Problem {
+	problem := &Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(res.GetStatus()),
+		Status:    res.GetStatus(),
+		Detail:    res.GetMessage(),
+		Code:      res.GetResCode(),
+		RequestId: RequestId(ctx),
+		Errors:    responseErrors(res),
+	}
+	if ctx.Request != nil {
+		problem.Instance = ctx.Request.URL.Path
+	}
+	return problem
+}
+
+// sendProblem writes the document as is, skipping the Envelope and Codecs
+// writeResponse applies.
+func sendProblem(ctx *gin.Context, problem *Problem) {
+	ctx.Header("Content-Type", ProblemContentType)
+	ctx.JSON(problem.Status, problem)
+	ctx.Abort()
+}
@@ -0,0 +1,116 @@
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveWithErrorFormat(t *testing.T, format ErrorFormat, accept string, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/blogs", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	return MockTestRouter(t, RouterConfig{ErrorFormat: format}, func(r Router) {
+		r.GetEngine().POST("/blogs", handler)
+	}, req)
+}
+
+func TestErrorFormat_Problem(t *testing.T) {
+	rr := serveWithErrorFormat(t, ErrorFormatProblem, "", func(ctx *gin.Context) {
+		SetRequestId(ctx, "req-1")
+		_, err := ValidateDto(&MockPayload{})
+		SendBadRequestError(ctx, err.Error(), err)
+	})
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, ProblemContentType, rr.Header().Get("Content-Type"))
+
+	var problem Problem
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	assert.Equal(t, Problem{
+		Type:      "about:blank",
+		Title:     "Bad Request",
+		Status:    http.StatusBadRequest,
+		Detail:    "field is required",
+		Instance:  "/blogs",
+		Code:      FailureCode,
+		RequestId: "req-1",
+		Errors:    []FieldError{{Field: "field", Tag: "required", Message: "field is required"}},
+	}, problem)
+}
+
+func TestErrorFormat_Negotiate(t *testing.T) {
+	handler := func(ctx *gin.Context) {
+		SendConflictError(ctx, "blog exists", nil)
+	}
+
+	rr := serveWithErrorFormat(t, ErrorFormatNegotiate, "application/problem+json, application/json;q=0.5", handler)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Equal(t, ProblemContentType, rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), `"title":"Conflict"`)
+
+	rr = serveWithErrorFormat(t, ErrorFormatNegotiate, "application/json", handler)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "application/json")
+	assert.Contains(t, rr.Body.String(), `"message":"blog exists"`)
+
+	rr = serveWithErrorFormat(t, ErrorFormatNegotiate, "application/problem+json;q=0", handler)
+	assert.Contains(t, rr.Body.String(), `"message":"blog exists"`)
+}
+
+func TestErrorFormat_NegotiateVaryAccept(t *testing.T) {
+	handler := func(ctx *gin.Context) {
+		SendConflictError(ctx, "blog exists", nil)
+	}
+
+	for _, accept := range []string{ProblemContentType, "application/json", ""} {
+		rr := serveWithErrorFormat(t, ErrorFormatNegotiate, accept, handler)
+		assert.Equal(t, []string{"Accept"}, rr.Header().Values("Vary"))
+	}
+
+	rr := serveWithErrorFormat(t, ErrorFormatProblem, ProblemContentType, handler)
+	assert.Empty(t, rr.Header().Values("Vary"))
+}
+
+func TestErrorFormat_ProblemSkipsEnvelopeAndCodecs(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/blogs", nil)
+	req.Header.Set("Accept", "application/xml")
+	config := RouterConfig{
+		ErrorFormat: ErrorFormatProblem,
+		Envelope: EnvelopeFunc(func(ctx *gin.Context, body *Body) any {
+			return gin.H{"wrapped": body.Message}
+		}),
+		Codecs: []Codec{NewXMLCodec()},
+	}
+	rr := MockTestRouter(t, config, func(r Router) {
+		r.GetEngine().POST("/blogs", func(ctx *gin.Context) {
+			SendConflictError(ctx, "blog exists", nil)
+		})
+	}, req)
+
+	assert.Equal(t, ProblemContentType, rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), `"detail":"blog exists"`)
+	assert.NotContains(t, rr.Body.String(), "wrapped")
+}
+
+func TestErrorFormat_Envelope(t *testing.T) {
+	rr := serveWithErrorFormat(t, ErrorFormatEnvelope, ProblemContentType, func(ctx *gin.Context) {
+		SendNotFoundError(ctx, "not found", nil)
+	})
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"message":"not found"`)
+	assert.NotContains(t, rr.Body.String(), `"title"`)
+}
+
+func TestErrorFormat_SuccessUnchanged(t *testing.T) {
+	rr := serveWithErrorFormat(t, ErrorFormatProblem, "", MockSuccessMsgHandler("ok"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"message":"ok"`)
+}
@@ -1,13 +1,25 @@
 package network
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 // ShouldBindJSON in gin internally used go-playground/validator i.e. why we have error with validaiton info
 func ReqBody[T any](ctx *gin.Context) (*T, error) {
 	var payload T
-	if err := ctx.ShouldBindJSON(&payload); err != nil {
+	var b binding.Binding = binding.JSON
+	if c := requestCodec(ctx); c != nil {
+		b = codecBinding{c}
+	}
+	if err := ctx.ShouldBindWith(&payload, b); err != nil {
 		e := processErrors(&payload, err)
 		return &payload, e
 	}
@@ -44,3 +56,287 @@ func ReqHeaders[T any](ctx *gin.Context) (*T, error) {
 
 	return ValidateDto(&payload)
 }
+
+// ReqAll binds the body, query, headers and uri params into one payload
+// by their json, form, header and uri tags, in that order so path params
+// can not be overridden, then validates it once so all failed fields are
+// reported together. Values that can not be converted to their field's
+// type are reported with the failed fields under the "type" tag. Embedded
+// DTOs like coredto.MongoId get their GetValue called once the payload is
+// valid.
+func ReqAll[T any](ctx *gin.Context) (*T, error) {
+	var payload T
+	bindErrs, err := bindAll(ctx, &payload)
+	if err != nil {
+		return &payload, err
+	}
+	value, err := ValidateDto(&payload)
+	if len(bindErrs) > 0 {
+		return value, mergeFieldErrors(bindErrs, err)
+	}
+	if err != nil {
+		return value, err
+	}
+	resolveEmbedded(reflect.ValueOf(value))
+	return value, nil
+}
+
+// bindAll returns the fields that could not be bound, binding the others,
+// and an error only when the body can not be read.
+func bindAll(ctx *gin.Context, obj any) ([]FieldError, error) {
+	var errs []FieldError
+	if req := ctx.Request; req.Body != nil && req.Body != http.NoBody && req.ContentLength != 0 {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > 0 {
+			var c Codec = jsonCodec{}
+			if rc := requestCodec(ctx); rc != nil {
+				c = rc
+			}
+			errs = append(errs, bindBody(obj, data, c)...)
+		}
+	}
+
+	formErrs, err := bindSource(obj, ctx.Request.URL.Query(), "form")
+	if err != nil {
+		return nil, err
+	}
+	errs = append(errs, formErrs...)
+
+	headers := make(map[string][]string)
+	for _, name := range tagNames(reflect.TypeOf(obj), "header") {
+		if values := ctx.Request.Header.Values(name); len(values) > 0 {
+			headers[name] = values
+		}
+	}
+	headerErrs, err := bindSource(obj, headers, "header")
+	if err != nil {
+		return nil, err
+	}
+	errs = append(errs, headerErrs...)
+
+	params := make(map[string][]string, len(ctx.Params))
+	for _, p := range ctx.Params {
+		params[p.Key] = []string{p.Value}
+	}
+	uriErrs, err := bindSource(obj, params, "uri")
+	if err != nil {
+		return nil, err
+	}
+	return append(errs, uriErrs...), nil
+}
+
+// bindBody decodes data onto the fields of obj not bound from a param
+// only, since decoders fall back to the field name for untagged fields.
+// A body that can not be decoded is reported as the "body" field.
+func bindBody(obj any, data []byte, c Codec) []FieldError {
+	dst := reflect.ValueOf(obj).Elem()
+	scratch := reflect.New(dst.Type())
+
+	var errs []FieldError
+	if err := c.Unmarshal(data, scratch.Interface()); err != nil {
+		// json keeps decoding the other fields after a type mismatch
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) || typeErr.Field == "" {
+			return []FieldError{newTypeError("body", c.ContentTypes()[0])}
+		}
+		errs = append(errs, newTypeError(typeErr.Field, typeErr.Type.String()))
+	}
+	copyFields(dst, scratch.Elem(), "json", func(field reflect.StructField, name string) bool {
+		if name != "" {
+			return true
+		}
+		for _, tag := range []string{"form", "header", "uri"} {
+			if _, ok := field.Tag.Lookup(tag); ok {
+				return false
+			}
+		}
+		return true
+	})
+	return errs
+}
+
+// bindSource maps values onto the fields of obj tagged with tag. gin falls
+// back to the field name for untagged fields, so values are mapped onto a
+// scratch copy and only the tagged fields that were sent, or have a
+// default, are copied over. Fields meant for other sources, like a json
+// only role, can not be set from the query, headers or path. Each value is
+// mapped on its own so the fields it can not be converted for are
+// returned, named as in validation errors.
+func bindSource(obj any, values map[string][]string, tag string) ([]FieldError, error) {
+	t := reflect.TypeOf(obj)
+	names := tagNames(t, tag)
+	if len(names) == 0 {
+		return nil, nil
+	}
+	dst := reflect.ValueOf(obj).Elem()
+
+	defaults := reflect.New(dst.Type())
+	if err := binding.MapFormWithTag(defaults.Interface(), map[string][]string{}, tag); err != nil {
+		return nil, err
+	}
+	copyFields(dst, defaults.Elem(), tag, func(field reflect.StructField, name string) bool {
+		_, sent := values[name]
+		return !sent && strings.Contains(field.Tag.Get(tag), ",default=")
+	})
+
+	var errs []FieldError
+	for _, name := range names {
+		value, ok := values[name]
+		if !ok {
+			continue
+		}
+		scratch := reflect.New(dst.Type())
+		if err := binding.MapFormWithTag(scratch.Interface(), map[string][]string{name: value}, tag); err != nil {
+			field, _ := fieldByTag(t, tag, name)
+			errs = append(errs, newTypeError(validationName(field), typeName(field.Type)))
+			continue
+		}
+		copyFields(dst, scratch.Elem(), tag, func(_ reflect.StructField, n string) bool {
+			return n == name
+		})
+	}
+	return errs, nil
+}
+
+// fieldByTag finds the field of t named name by tag, like tagNames.
+func fieldByTag(t reflect.Type, tag string, name string) (reflect.StructField, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		n, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		switch {
+		case n == "-":
+		case n != "":
+			if n == name {
+				return field, true
+			}
+		case field.Anonymous:
+			if f, ok := fieldByTag(field.Type, tag, name); ok {
+				return f, true
+			}
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// validationName is the name validation errors give field.
+func validationName(field reflect.StructField) string {
+	if name := CustomTagNameFunc()(field); name != "" {
+		return name
+	}
+	return field.Name
+}
+
+func typeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.String()
+}
+
+// copyFields copies the fields of src that keep selects by their name for
+// tag into dst, walking embedded structs without a name like tagNames.
+func copyFields(dst reflect.Value, src reflect.Value, tag string, keep func(field reflect.StructField, name string) bool) {
+	for i := range dst.NumField() {
+		field := dst.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+		d, s := dst.Field(i), src.Field(i)
+
+		if field.Anonymous && name == "" {
+			if s.Kind() == reflect.Pointer && s.Type().Elem().Kind() == reflect.Struct {
+				if s.IsNil() {
+					continue
+				}
+				if d.IsNil() {
+					if !d.CanSet() {
+						continue
+					}
+					d.Set(reflect.New(d.Type().Elem()))
+				}
+				d, s = d.Elem(), s.Elem()
+			}
+			if s.Kind() == reflect.Struct {
+				copyFields(d, s, tag, keep)
+				continue
+			}
+		}
+		if d.CanSet() && keep(field, name) {
+			d.Set(s)
+		}
+	}
+}
+
+// tagNames lists the names given by tag to the fields of t, including the
+// ones of embedded structs.
+func tagNames(t reflect.Type, tag string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		switch {
+		case name == "-":
+		case name != "":
+			names = append(names, name)
+		case field.Anonymous:
+			names = append(names, tagNames(field.Type, tag)...)
+		}
+	}
+	return names
+}
+
+// resolveEmbedded calls GetValue on embedded structs implementing Dto of
+// their own type, keeping the value it returns.
+func resolveEmbedded(v reflect.Value) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.Anonymous || !field.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Pointer && fv.IsNil() {
+			continue
+		}
+		resolveEmbedded(fv)
+
+		ptr := fv
+		if fv.Kind() != reflect.Pointer {
+			ptr = fv.Addr()
+		}
+		method := ptr.MethodByName("GetValue")
+		if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 || method.Type().Out(0) != ptr.Type() {
+			continue
+		}
+		if out := method.Call(nil)[0]; !out.IsNil() {
+			ptr.Elem().Set(out.Elem())
+		}
+	}
+}
@@ -139,3 +139,104 @@ func TestReqParam_mongo_id_Dto(t *testing.T) {
 
 	MockTestHandler(t, "POST", "/mock/id/:id", "/mock/id/"+id.Hex(), "", mockHandler, nil)
 }
+
+type allPayload struct {
+	coredto.MongoId
+	Tenant string `header:"x-tenant-id" validate:"required"`
+	Page   int64  `form:"page" validate:"min=1"`
+	Title  string `json:"title" validate:"required,min=2"`
+}
+
+func TestReqAll_Sources(t *testing.T) {
+	id := primitive.NewObjectID().Hex()
+
+	mockHandler := func(ctx *gin.Context) {
+		dto, err := ReqAll[allPayload](ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, id, dto.Id)
+		assert.Equal(t, id, dto.ID.Hex())
+		assert.Equal(t, "t1", dto.Tenant)
+		assert.Equal(t, int64(2), dto.Page)
+		assert.Equal(t, "hello", dto.Title)
+	}
+
+	MockTestHandler(t, "PUT", "/mock/:id", "/mock/"+id+"?page=2", `{"title":"hello","Id":"ignored"}`,
+		mockHandler, map[string]string{"X-Tenant-Id": "t1"})
+}
+
+func TestReqAll_ConsolidatedErrors(t *testing.T) {
+	mockHandler := func(ctx *gin.Context) {
+		_, err := ReqAll[allPayload](ctx)
+		var validationError *ValidationError
+		assert.ErrorAs(t, err, &validationError)
+
+		var fields []string
+		for _, e := range validationError.Errors {
+			fields = append(fields, e.Field+":"+e.Tag)
+		}
+		assert.ElementsMatch(t, []string{"id:len", "Tenant:required", "page:min", "title:required"}, fields)
+	}
+
+	MockTestHandler(t, "PUT", "/mock/:id", "/mock/123?page=0", `{}`, mockHandler, nil)
+}
+
+func TestReqAll_InvalidSource(t *testing.T) {
+	mockHandler := func(ctx *gin.Context) {
+		_, err := ReqAll[allPayload](ctx)
+		var validationError *ValidationError
+		assert.ErrorAs(t, err, &validationError)
+
+		var fields []string
+		for _, e := range validationError.Errors {
+			fields = append(fields, e.Field+":"+e.Tag)
+		}
+		// page is reported once, as the conversion failed and not as min
+		assert.ElementsMatch(t, []string{"page:type", "id:len", "Tenant:required"}, fields)
+		assert.Contains(t, err.Error(), "page must be a valid int64")
+	}
+
+	MockTestHandler(t, "PUT", "/mock/:id", "/mock/123?page=abc", `{"title":"hello"}`, mockHandler, nil)
+}
+
+func TestReqAll_InvalidBody(t *testing.T) {
+	mockHandler := func(ctx *gin.Context) {
+		dto, err := ReqAll[allPayload](ctx)
+		var validationError *ValidationError
+		assert.ErrorAs(t, err, &validationError)
+
+		var fields []string
+		for _, e := range validationError.Errors {
+			fields = append(fields, e.Field+":"+e.Tag)
+		}
+		assert.ElementsMatch(t, []string{"title:type", "id:len"}, fields)
+		assert.Equal(t, int64(2), dto.Page)
+	}
+
+	MockTestHandler(t, "PUT", "/mock/:id", "/mock/123?page=2", `{"title":5}`, mockHandler, map[string]string{"x-tenant-id": "t1"})
+}
+
+func TestReqAll_MalformedBody(t *testing.T) {
+	mockHandler := func(ctx *gin.Context) {
+		_, err := ReqAll[allPayload](ctx)
+		var validationError *ValidationError
+		assert.ErrorAs(t, err, &validationError)
+
+		var fields []string
+		for _, e := range validationError.Errors {
+			fields = append(fields, e.Field+":"+e.Tag)
+		}
+		assert.ElementsMatch(t, []string{"body:type", "id:len", "Tenant:required", "page:type", "title:required"}, fields)
+	}
+
+	MockTestHandler(t, "PUT", "/mock/:id", "/mock/123?page=x", `{"title":`, mockHandler, nil)
+}
+
+func TestReqAll_NoBody(t *testing.T) {
+	mockHandler := func(ctx *gin.Context) {
+		dto, err := ReqAll[coredto.MongoId](ctx)
+		assert.Error(t, err)
+		assert.Equal(t, "123", dto.Id)
+	}
+
+	MockTestHandler(t, "GET", "/mock/:id", "/mock/123", "", mockHandler, nil)
+}
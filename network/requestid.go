@@ -0,0 +1,43 @@
+package network
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+const requestIdKey = "requestId"
+
+type requestIdContextKey struct{}
+
+func ContextWithRequestId(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIdContextKey{}, id)
+}
+
+func RequestIdFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(requestIdContextKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// SetRequestId stores the id on the gin context and the request context
+// and echoes it in the response header.
+func SetRequestId(ctx *gin.Context, id string) {
+	ctx.Set(requestIdKey, id)
+	ctx.Request = ctx.Request.WithContext(ContextWithRequestId(ctx.Request.Context(), id))
+	ctx.Header(RequestIdHeader, id)
+}
+
+func RequestId(ctx *gin.Context) string {
+	if id := ctx.GetString(requestIdKey); id != "" {
+		return id
+	}
+	if ctx.Request != nil {
+		return RequestIdFromContext(ctx.Request.Context())
+	}
+	return ""
+}
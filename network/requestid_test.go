@@ -0,0 +1,32 @@
+package network
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWithRequestId(t *testing.T) {
+	ctx := ContextWithRequestId(context.Background(), "abc")
+	assert.Equal(t, "abc", RequestIdFromContext(ctx))
+	assert.Equal(t, "", RequestIdFromContext(context.Background()))
+}
+
+func TestSetRequestId(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	resp := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(resp)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	assert.Equal(t, "", RequestId(ctx))
+
+	SetRequestId(ctx, "abc")
+
+	assert.Equal(t, "abc", RequestId(ctx))
+	assert.Equal(t, "abc", RequestIdFromContext(ctx.Request.Context()))
+	assert.Equal(t, "abc", resp.Header().Get(RequestIdHeader))
+}
@@ -0,0 +1,55 @@
+package network
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+type ResCodeInfo struct {
+	Code    ResCode
+	Status  int
+	Message string
+}
+
+var resCodes = struct {
+	sync.RWMutex
+	m map[ResCode]ResCodeInfo
+}{
+	m: map[ResCode]ResCodeInfo{
+		SuccessCode: {Code: SuccessCode, Status: http.StatusOK, Message: "success"},
+		FailureCode: {Code: FailureCode, Status: http.StatusInternalServerError, Message: "failure"},
+	},
+}
+
+// RegisterResCode adds an application code with the status and message it
+// is sent with by default. It panics if the code is taken, so register
+// codes once at package init:
+//
+//	var TokenExpired = network.RegisterResCode("10003", http.StatusUnauthorized, "token expired")
+func RegisterResCode(code ResCode, status int, message string) ResCode {
+	resCodes.Lock()
+	defer resCodes.Unlock()
+	if _, ok := resCodes.m[code]; ok {
+		panic(fmt.Sprintf("rescode %s already registered", code))
+	}
+	resCodes.m[code] = ResCodeInfo{Code: code, Status: status, Message: message}
+	return code
+}
+
+func LookupResCode(code ResCode) (ResCodeInfo, bool) {
+	resCodes.RLock()
+	defer resCodes.RUnlock()
+	info, ok := resCodes.m[code]
+	return info, ok
+}
+
+// NewResCodeError creates an error with the registered status and message
+// of code. Unregistered codes become a 500.
+func NewResCodeError(code ResCode, err error) ApiError {
+	info, ok := LookupResCode(code)
+	if !ok {
+		return NewApiErrorWithResCode(http.StatusInternalServerError, code, fmt.Sprintf("unknown rescode %s", code), err)
+	}
+	return NewApiErrorWithResCode(info.Status, code, info.Message, err)
+}
@@ -0,0 +1,53 @@
+package network
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var testTokenExpired = RegisterResCode("19003", http.StatusUnauthorized, "token expired")
+
+func TestRegisterResCode(t *testing.T) {
+	info, ok := LookupResCode(testTokenExpired)
+	assert.True(t, ok)
+	assert.Equal(t, ResCodeInfo{Code: "19003", Status: http.StatusUnauthorized, Message: "token expired"}, info)
+
+	_, ok = LookupResCode(SuccessCode)
+	assert.True(t, ok)
+
+	assert.Panics(t, func() {
+		RegisterResCode(FailureCode, http.StatusBadRequest, "taken")
+	})
+}
+
+func TestNewResCodeError(t *testing.T) {
+	cause := errors.New("exp claim in the past")
+	apiErr := NewResCodeError(testTokenExpired, cause)
+
+	assert.Equal(t, http.StatusUnauthorized, apiErr.GetCode())
+	assert.Equal(t, testTokenExpired, apiErr.(resCoder).GetResCode())
+	assert.Equal(t, "token expired", apiErr.GetMessage())
+	assert.ErrorIs(t, apiErr, cause)
+
+	unknown := NewResCodeError("19999", nil)
+	assert.Equal(t, http.StatusInternalServerError, unknown.GetCode())
+}
+
+func TestSendResCodeError(t *testing.T) {
+	rr := MockTestHandler(t, "GET", "/mock", "/mock", "", func(ctx *gin.Context) {
+		SendResCodeError(ctx, testTokenExpired, nil)
+	}, nil)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"code":"19003"`)
+	assert.Contains(t, rr.Body.String(), `"message":"token expired"`)
+}
+
+func TestNewCustomResponse_RegisteredResCode(t *testing.T) {
+	res := NewCustomResponse[any](testTokenExpired, http.StatusUnauthorized, "token expired", nil)
+	assert.Equal(t, testTokenExpired, res.GetResCode())
+}
@@ -7,15 +7,17 @@ import (
 type ResCode string
 
 const (
-	success_code ResCode = "10000"
-	failue_code  ResCode = "10001"
+	SuccessCode ResCode = "10000"
+	FailureCode ResCode = "10001"
 )
 
 type response[T any] struct {
-	ResCode ResCode `json:"code" binding:"required"`
-	Status  int     `json:"status" binding:"required"`
-	Message string  `json:"message" binding:"required"`
-	Data    *T      `json:"data,omitempty" binding:"required,omitempty"`
+	ResCode ResCode `json:"code" xml:"code" binding:"required"`
+	Status  int     `json:"status" xml:"status" binding:"required"`
+	Message string  `json:"message" xml:"message" binding:"required"`
+	Data    *T      `json:"data,omitempty" xml:"data,omitempty" binding:"required,omitempty"`
+	// Errors lists the failed fields of a validation error response.
+	Errors []FieldError `json:"errors,omitempty" xml:"errors>error,omitempty"`
 }
 
 func (r *response[T]) GetResCode() ResCode {
@@ -34,6 +36,17 @@ func (r *response[T]) GetData() *T {
 	return r.Data
 }
 
+func (r *response[T]) GetErrors() []FieldError {
+	return r.Errors
+}
+
+func (r *response[T]) Payload() any {
+	if r.Data == nil {
+		return nil
+	}
+	return r.Data
+}
+
 func NewCustomResponse[T any](rescode ResCode, status int, message string, data *T) Response[T] {
 	return &response[T]{
 		ResCode: rescode,
@@ -45,7 +58,7 @@ func NewCustomResponse[T any](rescode ResCode, status int, message string, data
 
 func NewSuccessDataResponse[T any](message string, data *T) Response[T] {
 	return &response[T]{
-		ResCode: success_code,
+		ResCode: SuccessCode,
 		Status:  http.StatusOK,
 		Message: message,
 		Data:    data,
@@ -54,7 +67,7 @@ func NewSuccessDataResponse[T any](message string, data *T) Response[T] {
 
 func NewSuccessMsgResponse(message string) Response[any] {
 	return &response[any]{
-		ResCode: success_code,
+		ResCode: SuccessCode,
 		Status:  http.StatusOK,
 		Message: message,
 		Data:    nil,
@@ -63,7 +76,7 @@ func NewSuccessMsgResponse(message string) Response[any] {
 
 func NewBadRequestResponse(message string) Response[any] {
 	return &response[any]{
-		ResCode: failue_code,
+		ResCode: FailureCode,
 		Status:  http.StatusBadRequest,
 		Message: message,
 		Data:    nil,
@@ -72,7 +85,7 @@ func NewBadRequestResponse(message string) Response[any] {
 
 func NewForbiddenResponse(message string) Response[any] {
 	return &response[any]{
-		ResCode: failue_code,
+		ResCode: FailureCode,
 		Status:  http.StatusForbidden,
 		Message: message,
 		Data:    nil,
@@ -81,7 +94,7 @@ func NewForbiddenResponse(message string) Response[any] {
 
 func NewUnauthorizedResponse(message string) Response[any] {
 	return &response[any]{
-		ResCode: failue_code,
+		ResCode: FailureCode,
 		Status:  http.StatusUnauthorized,
 		Message: message,
 		Data:    nil,
@@ -90,7 +103,7 @@ func NewUnauthorizedResponse(message string) Response[any] {
 
 func NewNotFoundResponse(message string) Response[any] {
 	return &response[any]{
-		ResCode: failue_code,
+		ResCode: FailureCode,
 		Status:  http.StatusNotFound,
 		Message: message,
 		Data:    nil,
@@ -99,7 +112,7 @@ func NewNotFoundResponse(message string) Response[any] {
 
 func NewInternalServerErrorResponse(message string) Response[any] {
 	return &response[any]{
-		ResCode: failue_code,
+		ResCode: FailureCode,
 		Status:  http.StatusInternalServerError,
 		Message: message,
 		Data:    nil,
@@ -26,7 +26,7 @@ func TestNewSuccessDataResponse(t *testing.T) {
 	}
 	resp := NewSuccessDataResponse(message, &data)
 
-	assert.Equal(t, success_code, resp.GetResCode())
+	assert.Equal(t, SuccessCode, resp.GetResCode())
 	assert.Equal(t, "Success with data", resp.GetMessage())
 	assert.Equal(t, 200, resp.GetStatus())
 	assert.Equal(t, data, *resp.GetData())
@@ -36,7 +36,7 @@ func TestNewSuccessMsgResponse(t *testing.T) {
 	message := "Success message"
 	resp := NewSuccessMsgResponse(message)
 
-	assert.Equal(t, success_code, resp.GetResCode())
+	assert.Equal(t, SuccessCode, resp.GetResCode())
 	assert.Equal(t, "Success message", resp.GetMessage())
 	assert.Equal(t, 200, resp.GetStatus())
 	assert.Nil(t, resp.GetData())
@@ -46,7 +46,7 @@ func TestNewBadRequestResponse(t *testing.T) {
 	message := "Bad request"
 	resp := NewBadRequestResponse(message)
 
-	assert.Equal(t, failue_code, resp.GetResCode())
+	assert.Equal(t, FailureCode, resp.GetResCode())
 	assert.Equal(t, "Bad request", resp.GetMessage())
 	assert.Equal(t, 400, resp.GetStatus())
 	assert.Nil(t, resp.GetData())
@@ -56,7 +56,7 @@ func TestNewForbiddenResponse(t *testing.T) {
 	message := "Forbidden"
 	resp := NewForbiddenResponse(message)
 
-	assert.Equal(t, failue_code, resp.GetResCode())
+	assert.Equal(t, FailureCode, resp.GetResCode())
 	assert.Equal(t, "Forbidden", resp.GetMessage())
 	assert.Equal(t, 403, resp.GetStatus())
 	assert.Nil(t, resp.GetData())
@@ -66,7 +66,7 @@ func TestNewUnauthorizedResponse(t *testing.T) {
 	message := "Unauthorized"
 	resp := NewUnauthorizedResponse(message)
 
-	assert.Equal(t, failue_code, resp.GetResCode())
+	assert.Equal(t, FailureCode, resp.GetResCode())
 	assert.Equal(t, "Unauthorized", resp.GetMessage())
 	assert.Equal(t, 401, resp.GetStatus())
 	assert.Nil(t, resp.GetData())
@@ -76,7 +76,7 @@ func TestNewNotFoundResponse(t *testing.T) {
 	message := "Not found"
 	resp := NewNotFoundResponse(message)
 
-	assert.Equal(t, failue_code, resp.GetResCode())
+	assert.Equal(t, FailureCode, resp.GetResCode())
 	assert.Equal(t, "Not found", resp.GetMessage())
 	assert.Equal(t, 404, resp.GetStatus())
 	assert.Nil(t, resp.GetData())
@@ -86,7 +86,7 @@ func TestNewInternalServerErrorResponse(t *testing.T) {
 	message := "Internal server error"
 	resp := NewInternalServerErrorResponse(message)
 
-	assert.Equal(t, failue_code, resp.GetResCode())
+	assert.Equal(t, FailureCode, resp.GetResCode())
 	assert.Equal(t, "Internal server error", resp.GetMessage())
 	assert.Equal(t, 500, resp.GetStatus())
 	assert.Nil(t, resp.GetData())
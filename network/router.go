@@ -1,22 +1,120 @@
 package network
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/metrics"
+	"github.com/afteracademy/goserve/v2/tracing"
 	"github.com/gin-gonic/gin"
-	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	defaultShutdownTimeout = 10 * time.Second
+	defaultMetricsPath     = "/metrics"
+)
+
+type RouterConfig struct {
+	Mode            string
+	ShutdownTimeout time.Duration
+	// Logger replaces gin's default text request logger. Requests are then
+	// logged only through a request logging RootMiddleware.
+	Logger logger.Logger
+	// TracerProvider enables a server span for every request when set.
+	TracerProvider trace.TracerProvider
+	// Metrics are exposed on MetricsPath ("/metrics" by default) when set.
+	// Load middleware.NewMetrics to record the request metrics.
+	Metrics     *metrics.Metrics
+	MetricsPath string
+	// ErrorFormat selects how error responses are written, the
+	// {code,status,message} envelope by default.
+	ErrorFormat ErrorFormat
+	// Envelope replaces the JSON shape of all responses when set.
+	Envelope Envelope
+	// Codecs render responses for clients whose Accept prefers them and
+	// bind request bodies by Content-Type. JSON is always available and is
+	// sent instead when the preferred codec can not encode a response.
+	Codecs []Codec
+	// OpenAPI serves a document of the routes and a docs page when set.
+	// Routes mounted by controllers with Describe or the typed route
+	// helpers add their types and auth.
+	OpenAPI *OpenAPIConfig
+}
+
 type router struct {
-	engine *gin.Engine
+	engine        *gin.Engine
+	config        RouterConfig
+	logger        logger.Logger
+	startHooks    []LifecycleHook
+	shutdownHooks []LifecycleHook
+	routes        *routeRegistry
 }
 
 func NewRouter(mode string) Router {
-	gin.SetMode(mode)
-	eng := gin.Default()
+	return NewRouterWithConfig(RouterConfig{Mode: mode})
+}
+
+func NewRouterWithConfig(config RouterConfig) Router {
+	gin.SetMode(config.Mode)
+	// registers the custom tags on gin's validator before any binding
+	Validator()
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = defaultShutdownTimeout
+	}
+	var eng *gin.Engine
+	if config.Logger == nil {
+		eng = gin.Default()
+	} else {
+		eng = gin.New()
+		eng.Use(gin.Recovery())
+	}
+	if config.ErrorFormat != ErrorFormatEnvelope {
+		eng.Use(errorFormatHandler(config.ErrorFormat))
+	}
+	if config.Envelope != nil {
+		eng.Use(envelopeHandler(config.Envelope))
+	}
+	if len(config.Codecs) > 0 {
+		eng.Use(codecsHandler(config.Codecs))
+	}
+	if config.TracerProvider != nil {
+		eng.Use(tracingHandler(tracing.Tracer(config.TracerProvider)))
+	}
+	if config.Metrics != nil {
+		if config.MetricsPath == "" {
+			config.MetricsPath = defaultMetricsPath
+		}
+		eng.GET(config.MetricsPath, gin.WrapH(config.Metrics.Handler()))
+	}
+	if config.OpenAPI != nil {
+		// copied so the defaults are not written into the caller's config
+		openAPI := *config.OpenAPI
+		if openAPI.SpecPath == "" {
+			openAPI.SpecPath = defaultSpecPath
+		}
+		if openAPI.DocsPath == "" {
+			openAPI.DocsPath = defaultDocsPath
+		}
+		config.OpenAPI = &openAPI
+	}
 	r := router{
 		engine: eng,
+		config: config,
+		logger: logger.Or(config.Logger),
+		routes: newRouteRegistry(),
+	}
+	if config.OpenAPI != nil {
+		openAPIHandlers(eng, *config.OpenAPI, r.routes)
 	}
 	return &r
 }
@@ -34,17 +132,118 @@ func (r *router) LoadRootMiddlewares(middlewares []RootMiddleware) {
 func (r *router) LoadControllers(controllers []Controller) {
 	for _, c := range controllers {
 		g := r.engine.Group(c.Path())
-		c.MountRoutes(g)
+		r.routes.mount(func() { c.MountRoutes(g) })
 	}
 }
 
+func (r *router) OnStart(hooks ...LifecycleHook) {
+	r.startHooks = append(r.startHooks, hooks...)
+}
+
+func (r *router) OnShutdown(hooks ...LifecycleHook) {
+	r.shutdownHooks = append(r.shutdownHooks, hooks...)
+}
+
 func (r *router) Start(ip string, port uint16) {
 	address := fmt.Sprintf("%s:%d", ip, port)
 	r.engine.Run(address)
 }
 
+// StartGracefully serves until SIGINT or SIGTERM is received, then drains
+// in-flight requests and runs the shutdown hooks.
+func (r *router) StartGracefully(ip string, port uint16) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return r.Serve(ctx, ip, port)
+}
+
+// Serve runs the start hooks, serves until ctx is done and then shuts the
+// server down within RouterConfig.ShutdownTimeout before running the
+// shutdown hooks in the order they were registered. The shutdown hooks
+// also run when a start hook or the listener fails, releasing whatever
+// the hooks before it started.
+func (r *router) Serve(ctx context.Context, ip string, port uint16) error {
+	for _, hook := range r.startHooks {
+		if err := hook(ctx); err != nil {
+			return errors.Join(fmt.Errorf("start hook failed: %w", err), r.shutdownHooksOnly())
+		}
+	}
+
+	address := fmt.Sprintf("%s:%d", ip, port)
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return errors.Join(err, r.shutdownHooksOnly())
+	}
+
+	server := &http.Server{
+		Handler: r.engine.Handler(),
+	}
+	r.logger.Info("server listening", "address", listener.Addr().String())
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	var errs []error
+	select {
+	case <-ctx.Done():
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			errs = append(errs, err)
+		}
+	}
+
+	r.logger.Info("server shutting down", "timeout", r.config.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), r.config.ShutdownTimeout)
+	defer cancel()
+
+	errs = append(errs, r.shutdown(shutdownCtx, server))
+	err = errors.Join(errs...)
+	if err != nil {
+		r.logger.Error("server stopped with errors", "error", err)
+	} else {
+		r.logger.Info("server stopped")
+	}
+	return err
+}
+
+// shutdownHooksOnly runs the shutdown hooks when the server never started
+func (r *router) shutdownHooksOnly() error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.ShutdownTimeout)
+	defer cancel()
+	return r.shutdown(ctx, nil)
+}
+
+func (r *router) shutdown(ctx context.Context, server *http.Server) error {
+	var errs []error
+	if server != nil {
+		if err := server.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("server shutdown failed: %w", err))
+		}
+	}
+	for _, hook := range r.shutdownHooks {
+		if err := hook(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown hook failed: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (r *router) RegisterValidationParsers(tagNameFunc validator.TagNameFunc) {
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterTagNameFunc(tagNameFunc)
+	if gv := bindingValidator(); gv != nil {
+		gv.RegisterTagNameFunc(tagNameFunc)
 	}
 }
+
+func (r *router) RegisterValidation(tag string, fn validator.Func) error {
+	return RegisterValidation(tag, fn)
+}
+
+func (r *router) RegisterStructValidation(fn validator.StructLevelFunc, types ...any) {
+	RegisterStructValidation(fn, types...)
+}
+
+func (r *router) RegisterAlias(alias string, tags string) {
+	RegisterAlias(alias, tags)
+}
@@ -0,0 +1,154 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func freePort(t *testing.T) uint16 {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not reserve port: %v", err)
+	}
+	defer l.Close()
+	return uint16(l.Addr().(*net.TCPAddr).Port)
+}
+
+func TestNewRouterWithConfig(t *testing.T) {
+	r := NewRouterWithConfig(RouterConfig{Mode: gin.TestMode})
+	assert.NotNil(t, r.GetEngine())
+	assert.Equal(t, defaultShutdownTimeout, r.(*router).config.ShutdownTimeout)
+}
+
+func TestRouter_Serve_RunsHooksInOrder(t *testing.T) {
+	r := NewRouterWithConfig(RouterConfig{Mode: gin.TestMode, ShutdownTimeout: time.Second})
+
+	var calls []string
+	r.OnStart(
+		func(ctx context.Context) error { calls = append(calls, "start1"); return nil },
+		func(ctx context.Context) error { calls = append(calls, "start2"); return nil },
+	)
+	r.OnShutdown(func(ctx context.Context) error { calls = append(calls, "shutdown1"); return nil })
+	r.OnShutdown(func(ctx context.Context) error { calls = append(calls, "shutdown2"); return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := r.Serve(ctx, "127.0.0.1", freePort(t))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"start1", "start2", "shutdown1", "shutdown2"}, calls)
+}
+
+func TestRouter_Serve_StartHookError(t *testing.T) {
+	r := NewRouterWithConfig(RouterConfig{Mode: gin.TestMode})
+
+	shutdownCalled := false
+	r.OnStart(func(ctx context.Context) error { return errors.New("boom") })
+	r.OnShutdown(func(ctx context.Context) error { shutdownCalled = true; return nil })
+
+	err := r.Serve(context.Background(), "127.0.0.1", freePort(t))
+	assert.ErrorContains(t, err, "boom")
+	assert.True(t, shutdownCalled, "hooks started before the failure must be released")
+}
+
+func TestRouter_Serve_StartHookErrorJoinsShutdownError(t *testing.T) {
+	r := NewRouterWithConfig(RouterConfig{Mode: gin.TestMode})
+
+	r.OnStart(func(ctx context.Context) error { return errors.New("boom") })
+	r.OnShutdown(func(ctx context.Context) error { return errors.New("close failed") })
+
+	err := r.Serve(context.Background(), "127.0.0.1", freePort(t))
+	assert.ErrorContains(t, err, "boom")
+	assert.ErrorContains(t, err, "close failed")
+}
+
+func TestRouter_Serve_ShutdownHookError(t *testing.T) {
+	r := NewRouterWithConfig(RouterConfig{Mode: gin.TestMode})
+
+	secondCalled := false
+	r.OnShutdown(func(ctx context.Context) error { return errors.New("close failed") })
+	r.OnShutdown(func(ctx context.Context) error { secondCalled = true; return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := r.Serve(ctx, "127.0.0.1", freePort(t))
+	assert.ErrorContains(t, err, "close failed")
+	assert.True(t, secondCalled)
+}
+
+func TestRouter_Serve_DrainsInFlightRequests(t *testing.T) {
+	r := NewRouterWithConfig(RouterConfig{Mode: gin.TestMode, ShutdownTimeout: 5 * time.Second})
+
+	started := make(chan struct{})
+	r.GetEngine().GET("/slow", func(ctx *gin.Context) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		SendSuccessMsgResponse(ctx, "done")
+	})
+
+	port := freePort(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- r.Serve(ctx, "127.0.0.1", port)
+	}()
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/slow", port)
+	var resp *http.Response
+	var reqErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			resp, reqErr = http.Get(url)
+			if reqErr == nil {
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+
+	<-started
+	cancel()
+	<-done
+
+	assert.NoError(t, reqErr)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	assert.NoError(t, <-served)
+}
+
+func TestNewRouterWithConfig_Logger(t *testing.T) {
+	withDefault := NewRouterWithConfig(RouterConfig{Mode: gin.TestMode})
+	withLogger := NewRouterWithConfig(RouterConfig{Mode: gin.TestMode, Logger: logger.Nop()})
+
+	// gin.Default installs the text logger and recovery, custom logger only recovery
+	assert.Len(t, withDefault.GetEngine().Handlers, 2)
+	assert.Len(t, withLogger.GetEngine().Handlers, 1)
+}
+
+func TestNewRouterWithConfig_MetricsRoute(t *testing.T) {
+	m := metrics.New(metrics.Config{Namespace: "test"})
+	m.ObserveApiError(http.StatusNotFound)
+
+	r := NewRouterWithConfig(RouterConfig{Mode: gin.TestMode, Metrics: m, MetricsPath: "/internal/metrics"})
+
+	rr := httptest.NewRecorder()
+	r.GetEngine().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/internal/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `test_api_errors_total{code="404"} 1`)
+}
@@ -39,6 +39,30 @@ func SendInternalServerError(ctx *gin.Context, message string, err error) {
 	sendError(ctx, NewInternalServerError(message, err))
 }
 
+func SendConflictError(ctx *gin.Context, message string, err error) {
+	sendError(ctx, NewConflictError(message, err))
+}
+
+func SendGoneError(ctx *gin.Context, message string, err error) {
+	sendError(ctx, NewGoneError(message, err))
+}
+
+func SendUnprocessableEntityError(ctx *gin.Context, message string, err error) {
+	sendError(ctx, NewUnprocessableEntityError(message, err))
+}
+
+func SendTooManyRequestsError(ctx *gin.Context, message string, err error) {
+	sendError(ctx, NewTooManyRequestsError(message, err))
+}
+
+func SendServiceUnavailableError(ctx *gin.Context, message string, err error) {
+	sendError(ctx, NewServiceUnavailableError(message, err))
+}
+
+func SendResCodeError(ctx *gin.Context, code ResCode, err error) {
+	sendError(ctx, NewResCodeError(code, err))
+}
+
 func SendMixedError(ctx *gin.Context, err error) {
 	if err == nil {
 		SendInternalServerError(ctx, "something went wrong", err)
@@ -59,45 +83,52 @@ func sendResponse[T any](ctx *gin.Context, response Response[T]) {
 	if data != nil {
 		_, err := ValidateDto(data)
 		if err != nil {
-			res := NewInternalServerErrorResponse(err.Error())
-			ctx.JSON(int(res.GetStatus()), res)
+			writeResponse(ctx, NewInternalServerErrorResponse(err.Error()))
 			ctx.Abort()
 			return
 		}
 	}
 
-	ctx.JSON(int(response.GetStatus()), response)
+	writeResponse(ctx, response)
 	// this is needed since gin calls ctx.Next() inside the resposne handeling
 	// ref: https://github.com/gin-gonic/gin/issues/2221
 	ctx.Abort()
 }
 
 func sendError(ctx *gin.Context, err ApiError) {
+	// recorded so middlewares can observe the error after the handler returns
+	ctx.Error(err)
+
 	var debug = gin.Mode() != gin.ReleaseMode
-	var res Response[any]
-
-	switch err.GetCode() {
-	case http.StatusBadRequest:
-		res = NewBadRequestResponse(err.GetMessage())
-	case http.StatusForbidden:
-		res = NewForbiddenResponse(err.GetMessage())
-	case http.StatusUnauthorized:
-		res = NewUnauthorizedResponse(err.GetMessage())
-	case http.StatusNotFound:
-		res = NewNotFoundResponse(err.GetMessage())
-	case http.StatusInternalServerError:
-		if debug {
-			res = NewInternalServerErrorResponse(err.Unwrap().Error())
-		}
-	default:
+
+	status := err.GetCode()
+	message := err.GetMessage()
+
+	// 500s and unknown codes hide the cause outside debug mode
+	if status == http.StatusInternalServerError || status < 400 || status >= 600 {
+		status = http.StatusInternalServerError
+		message = "An unexpected error occurred. Please try again later."
 		if debug {
-			res = NewInternalServerErrorResponse(err.Unwrap().Error())
+			message = err.Unwrap().Error()
 		}
 	}
 
-	if res == nil {
-		res = NewInternalServerErrorResponse("An unexpected error occurred. Please try again later.")
+	rescode := FailureCode
+	if rc, ok := err.(resCoder); ok {
+		rescode = rc.GetResCode()
+	}
+
+	res := &response[any]{
+		ResCode: rescode,
+		Status:  status,
+		Message: message,
+		Errors:  fieldErrors(err),
+	}
+
+	if wantsProblem(ctx) {
+		sendProblem(ctx, newProblem(ctx, res))
+		return
 	}
 
-	sendResponse(ctx, res)
+	sendResponse[any](ctx, res)
 }
@@ -20,7 +20,7 @@ func TestSend_MixedError_Nil(t *testing.T) {
 	SendMixedError(ctx, nil)
 
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
-	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, failue_code))
+	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, FailureCode))
 	assert.Contains(t, resp.Body.String(), `"message":"something went wrong"`)
 }
 
@@ -32,7 +32,7 @@ func TestSend_MixedError_Err(t *testing.T) {
 	err := errors.New("test error")
 	SendMixedError(ctx, err)
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
-	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, failue_code))
+	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, FailureCode))
 	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"message":"%s"`, err.Error()))
 }
 
@@ -44,7 +44,7 @@ func TestSend_MixedError_UnauthorizedError(t *testing.T) {
 	err := NewUnauthorizedError("test message", nil)
 	SendMixedError(ctx, err)
 	assert.Equal(t, http.StatusUnauthorized, resp.Code)
-	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, failue_code))
+	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, FailureCode))
 	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"message":"%s"`, "test message"))
 }
 
@@ -56,7 +56,7 @@ func TestSend_SuccessMsgResponse(t *testing.T) {
 	SendSuccessMsgResponse(ctx, "test message")
 
 	assert.Equal(t, http.StatusOK, resp.Code)
-	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, success_code))
+	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, SuccessCode))
 	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"message":"%s"`, "test message"))
 }
 
@@ -72,7 +72,7 @@ func TestSend_SuccessDataResponse_Error(t *testing.T) {
 	SendSuccessDataResponse(ctx, "test message", data)
 
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
-	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, failue_code))
+	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, FailureCode))
 	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"message":"%s"`, "field must be at least 2 characters"))
 	assert.NotContains(t, resp.Body.String(), fmt.Sprintf(`"data":%s`, `{"field":"test data"}`))
 }
@@ -91,7 +91,69 @@ func TestSend_SuccessDataResponse(t *testing.T) {
 	SendSuccessDataResponse(ctx, "test message", &data)
 
 	assert.Equal(t, http.StatusOK, resp.Code)
-	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, success_code))
+	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, SuccessCode))
 	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"message":"%s"`, "test message"))
 	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"data":%s`, `{"field":"test data"}`))
 }
+
+func TestSend_StatusErrors(t *testing.T) {
+	cases := map[int]func(*gin.Context, string, error){
+		http.StatusConflict:            SendConflictError,
+		http.StatusGone:                SendGoneError,
+		http.StatusUnprocessableEntity: SendUnprocessableEntityError,
+		http.StatusTooManyRequests:     SendTooManyRequestsError,
+		http.StatusServiceUnavailable:  SendServiceUnavailableError,
+	}
+
+	gin.SetMode(gin.ReleaseMode)
+	defer gin.SetMode(gin.TestMode)
+
+	for code, send := range cases {
+		resp := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(resp)
+
+		send(ctx, "test message", errors.New("hidden"))
+
+		assert.Equal(t, code, resp.Code)
+		assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, FailureCode))
+		assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"status":%d`, code))
+		assert.Contains(t, resp.Body.String(), `"message":"test message"`)
+		assert.NotContains(t, resp.Body.String(), "hidden")
+	}
+}
+
+func TestSend_MixedError_ArbitraryCode(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	defer gin.SetMode(gin.TestMode)
+
+	resp := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(resp)
+	SendMixedError(ctx, NewApiError(http.StatusRequestEntityTooLarge, "too large", nil))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"message":"too large"`)
+
+	resp = httptest.NewRecorder()
+	ctx, _ = gin.CreateTestContext(resp)
+	SendMixedError(ctx, NewApiError(http.StatusFound, "redirect", nil))
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.NotContains(t, resp.Body.String(), "redirect")
+}
+
+// legacyApiError implements only the original ApiError methods
+type legacyApiError struct{}
+
+func (legacyApiError) GetCode() int       { return http.StatusConflict }
+func (legacyApiError) GetMessage() string { return "already exists" }
+func (legacyApiError) Error() string      { return "already exists" }
+func (legacyApiError) Unwrap() error      { return nil }
+
+func TestSend_MixedError_CustomApiError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	resp := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(resp)
+
+	SendMixedError(ctx, fmt.Errorf("wrapped: %w", legacyApiError{}))
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, FailureCode))
+	assert.Contains(t, resp.Body.String(), `"message":"already exists"`)
+}
@@ -0,0 +1,171 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	EventStreamContentType = "text/event-stream"
+	NDJSONContentType      = "application/x-ndjson"
+	LastEventIdHeader      = "Last-Event-ID"
+	DefaultHeartbeat       = 15 * time.Second
+)
+
+// Event is a single Server-Sent Event. Data is sent as JSON, and Name and
+// Id are omitted when empty. Retry tells the client how long to wait
+// before reconnecting.
+type Event[T any] struct {
+	Id    string
+	Name  string
+	Data  T
+	Retry time.Duration
+}
+
+// LastEventId is the id of the last event the client received before
+// reconnecting, used to resume a stream. EventSource polyfills that can
+// not set headers send it as the lastEventId query param instead.
+func LastEventId(ctx *gin.Context) string {
+	if id := ctx.GetHeader(LastEventIdHeader); id != "" {
+		return id
+	}
+	return ctx.Query("lastEventId")
+}
+
+// SendEventStream writes events as they arrive on the channel until it is
+// closed or the client disconnects, sending a comment every heartbeat to
+// keep idle connections open. A heartbeat <= 0 uses DefaultHeartbeat.
+// It returns the client context error on disconnect so producers can stop.
+func SendEventStream[T any](ctx *gin.Context, events <-chan Event[T], heartbeat time.Duration) error {
+	if heartbeat <= 0 {
+		heartbeat = DefaultHeartbeat
+	}
+
+	header := ctx.Writer.Header()
+	header.Set("Content-Type", EventStreamContentType)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// disables response buffering in nginx
+	header.Set("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+	defer ctx.Abort()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	done := ctx.Request.Context().Done()
+	for {
+		select {
+		case <-done:
+			return ctx.Request.Context().Err()
+		case <-ticker.C:
+			if _, err := ctx.Writer.WriteString(": ping\n\n"); err != nil {
+				return err
+			}
+			ctx.Writer.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			data, err := encodeEvent(event)
+			if err != nil {
+				return err
+			}
+			if _, err := ctx.Writer.Write(data); err != nil {
+				return err
+			}
+			ctx.Writer.Flush()
+		}
+	}
+}
+
+func encodeEvent[T any](event Event[T]) ([]byte, error) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if event.Id != "" {
+		buf.WriteString("id: " + sanitizeEventField(event.Id) + "\n")
+	}
+	if event.Name != "" {
+		buf.WriteString("event: " + sanitizeEventField(event.Name) + "\n")
+	}
+	if event.Retry > 0 {
+		buf.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+	buf.WriteString("data: ")
+	buf.Write(data)
+	buf.WriteString("\n\n")
+	return buf.Bytes(), nil
+}
+
+// sanitizeEventField drops line breaks which would end the field early
+func sanitizeEventField(v string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(v)
+}
+
+// SendNDJSON streams items as newline delimited JSON, flushing each line,
+// so large exports are never held in memory. An error before the first
+// item is sent as a regular error response; once streaming has started
+// the status is already written, so the stream is cut short and the error
+// is returned and recorded on the context.
+func SendNDJSON[T any](ctx *gin.Context, items iter.Seq2[T, error]) error {
+	defer ctx.Abort()
+
+	started := false
+	done := ctx.Request.Context().Done()
+	for item, err := range items {
+		if err != nil {
+			if !started {
+				SendMixedError(ctx, err)
+				return err
+			}
+			ctx.Error(err)
+			return err
+		}
+
+		select {
+		case <-done:
+			return ctx.Request.Context().Err()
+		default:
+		}
+
+		data, err := json.Marshal(item)
+		if err != nil {
+			if !started {
+				SendInternalServerError(ctx, "something went wrong", err)
+				return err
+			}
+			ctx.Error(err)
+			return err
+		}
+
+		if !started {
+			ctx.Writer.Header().Set("Content-Type", NDJSONContentType)
+			ctx.Status(http.StatusOK)
+			started = true
+		}
+		data = append(data, '\n')
+		if _, err := ctx.Writer.Write(data); err != nil {
+			return err
+		}
+		ctx.Writer.Flush()
+	}
+
+	if !started {
+		ctx.Writer.Header().Set("Content-Type", NDJSONContentType)
+		ctx.Status(http.StatusOK)
+		ctx.Writer.WriteHeaderNow()
+	}
+	return nil
+}
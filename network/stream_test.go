@@ -0,0 +1,137 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveStream(t *testing.T, req *http.Request, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	return MockTestRouter(t, RouterConfig{}, func(r Router) {
+		r.GetEngine().GET("/", handler)
+	}, req)
+}
+
+func TestSendEventStream(t *testing.T) {
+	events := make(chan Event[MockPayload], 2)
+	events <- Event[MockPayload]{Id: "1", Name: "created", Data: MockPayload{Field: "a"}}
+	events <- Event[MockPayload]{Id: "2\n", Data: MockPayload{Field: "b"}, Retry: 3 * time.Second}
+	close(events)
+
+	var streamErr error
+	rr := serveStream(t, httptest.NewRequest(http.MethodGet, "/", nil), func(ctx *gin.Context) {
+		streamErr = SendEventStream(ctx, events, time.Hour)
+	})
+
+	assert.NoError(t, streamErr)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, EventStreamContentType, rr.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", rr.Header().Get("Cache-Control"))
+	assert.Equal(t,
+		"id: 1\nevent: created\ndata: {\"field\":\"a\"}\n\n"+
+			"id: 2\nretry: 3000\ndata: {\"field\":\"b\"}\n\n",
+		rr.Body.String())
+}
+
+func TestSendEventStream_Heartbeat(t *testing.T) {
+	events := make(chan Event[string])
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(events)
+	}()
+
+	rr := serveStream(t, httptest.NewRequest(http.MethodGet, "/", nil), func(ctx *gin.Context) {
+		SendEventStream(ctx, events, 10*time.Millisecond)
+	})
+
+	assert.Contains(t, rr.Body.String(), ": ping\n\n")
+}
+
+func TestSendEventStream_ClientDisconnect(t *testing.T) {
+	c, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(c)
+	events := make(chan Event[string])
+	cancel()
+
+	var streamErr error
+	serveStream(t, req, func(ctx *gin.Context) {
+		streamErr = SendEventStream(ctx, events, time.Hour)
+	})
+
+	assert.ErrorIs(t, streamErr, context.Canceled)
+}
+
+func TestLastEventId(t *testing.T) {
+	var id string
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(LastEventIdHeader, "42")
+	serveStream(t, req, func(ctx *gin.Context) { id = LastEventId(ctx) })
+	assert.Equal(t, "42", id)
+
+	serveStream(t, httptest.NewRequest(http.MethodGet, "/?lastEventId=7", nil), func(ctx *gin.Context) { id = LastEventId(ctx) })
+	assert.Equal(t, "7", id)
+}
+
+func seq[T any](items []T, err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+func TestSendNDJSON(t *testing.T) {
+	items := []*MockPayload{{Field: "a"}, {Field: "b"}}
+	rr := serveStream(t, httptest.NewRequest(http.MethodGet, "/", nil), func(ctx *gin.Context) {
+		assert.NoError(t, SendNDJSON(ctx, seq(items, nil)))
+	})
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, NDJSONContentType, rr.Header().Get("Content-Type"))
+	assert.Equal(t, "{\"field\":\"a\"}\n{\"field\":\"b\"}\n", rr.Body.String())
+}
+
+func TestSendNDJSON_Empty(t *testing.T) {
+	rr := serveStream(t, httptest.NewRequest(http.MethodGet, "/", nil), func(ctx *gin.Context) {
+		assert.NoError(t, SendNDJSON(ctx, seq[*MockPayload](nil, nil)))
+	})
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, NDJSONContentType, rr.Header().Get("Content-Type"))
+	assert.Empty(t, rr.Body.String())
+}
+
+func TestSendNDJSON_ErrorBeforeFirstItem(t *testing.T) {
+	rr := serveStream(t, httptest.NewRequest(http.MethodGet, "/", nil), func(ctx *gin.Context) {
+		SendNDJSON(ctx, seq[*MockPayload](nil, NewNotFoundError("no export", nil)))
+	})
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"message":"no export"`)
+}
+
+func TestSendNDJSON_ErrorMidStream(t *testing.T) {
+	failure := errors.New("cursor failed")
+	var streamErr error
+	rr := serveStream(t, httptest.NewRequest(http.MethodGet, "/", nil), func(ctx *gin.Context) {
+		streamErr = SendNDJSON(ctx, seq([]*MockPayload{{Field: "a"}}, failure))
+	})
+
+	assert.ErrorIs(t, streamErr, failure)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 1, strings.Count(rr.Body.String(), "\n"))
+}
@@ -0,0 +1,51 @@
+package network
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/afteracademy/goserve/v2/tracing"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func tracingHandler(tracer trace.Tracer) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		req := ctx.Request
+		route := ctx.FullPath()
+
+		spanName := req.Method
+		if route != "" {
+			spanName = fmt.Sprintf("%s %s", req.Method, route)
+		}
+
+		parent := tracing.Extract(req.Context(), req.Header)
+		spanCtx, span := tracer.Start(parent, spanName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", req.Method),
+				attribute.String("url.path", req.URL.Path),
+				attribute.String("http.route", route),
+				attribute.String("client.address", ctx.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		ctx.Request = req.WithContext(spanCtx)
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if id := RequestId(ctx); id != "" {
+			span.SetAttributes(attribute.String("request.id", id))
+		}
+		for _, err := range ctx.Errors {
+			span.RecordError(err.Err)
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package network
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/afteracademy/goserve/v2/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRouter_TracingServerSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	r := NewRouterWithConfig(RouterConfig{Mode: gin.TestMode, TracerProvider: tp})
+
+	var handlerSpan trace.SpanContext
+	r.GetEngine().GET("/blogs/:id", func(ctx *gin.Context) {
+		handlerSpan = trace.SpanContextFromContext(ctx.Request.Context())
+		SendSuccessMsgResponse(ctx, "ok")
+	})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/blogs/42", nil)
+	r.GetEngine().ServeHTTP(rr, req)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET /blogs/:id", span.Name)
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+	assert.Equal(t, span.SpanContext.SpanID(), handlerSpan.SpanID())
+	assert.Contains(t, span.Attributes, attribute.Int("http.response.status_code", http.StatusOK))
+	assert.Contains(t, span.Attributes, attribute.String("http.route", "/blogs/:id"))
+}
+
+func TestRouter_TracingPropagatesParentAndError(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	r := NewRouterWithConfig(RouterConfig{Mode: gin.TestMode, TracerProvider: tp})
+	r.GetEngine().GET("/fail", func(ctx *gin.Context) {
+		SendInternalServerError(ctx, "failed", nil)
+	})
+
+	parentCtx, parent := tracing.Tracer(tp).Start(context.Background(), "client")
+	req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+	tracing.Inject(parentCtx, req.Header)
+	parent.End()
+
+	rr := httptest.NewRecorder()
+	r.GetEngine().ServeHTTP(rr, req)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	server := spans[1]
+	assert.Equal(t, parent.SpanContext().TraceID(), server.SpanContext.TraceID())
+	assert.Equal(t, parent.SpanContext().SpanID(), server.Parent.SpanID())
+	assert.Equal(t, codes.Error, server.Status.Code)
+}
+
+func TestRouter_NoTracingWithoutProvider(t *testing.T) {
+	r := NewRouterWithConfig(RouterConfig{Mode: gin.TestMode})
+	assert.Len(t, r.GetEngine().Handlers, 2)
+}
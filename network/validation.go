@@ -5,7 +5,6 @@ import (
 	"reflect"
 	"strings"
 
-	"github.com/afteracademy/goserve/v2/utility"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -30,9 +29,7 @@ func ValidateDto[T any](payload *T) (*T, error) {
 		return payload, errors.New("only struct payloads are valid for validation")
 	}
 
-	v := validator.New()
-	v.RegisterTagNameFunc(CustomTagNameFunc())
-	if err := v.Struct(payload); err != nil {
+	if err := Validator().Struct(payload); err != nil {
 		e := processErrors(payload, err)
 		return payload, e
 	}
@@ -46,29 +43,35 @@ func ValidateDto[T any](payload *T) (*T, error) {
 
 // payload should be a pointer to struct
 func processErrors[T any](payload *T, err error) error {
-	if validationErrors, ok := err.(validator.ValidationErrors); ok {
-		var msgs []string
-		if d, ok := any(payload).(DtoV[T]); ok {
-			vmsgs, e := d.ValidateErrors(validationErrors)
-			if e != nil {
-				return e
-			}
-			msgs = vmsgs
-		} else {
-			msgs = utility.FormatValidationErrors(err)
-		}
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
 
-		var msg strings.Builder
-		br := ", "
-		for _, m := range msgs {
-			msg.WriteString(m + br)
+	if d, ok := any(payload).(DtoV[T]); ok {
+		msgs, e := d.ValidateErrors(validationErrors)
+		if e != nil {
+			return e
 		}
-		// Remove the trailing separator
-		errorMsg := msg.String()
-		if len(errorMsg) > 0 {
-			errorMsg = errorMsg[:len(errorMsg)-len(br)]
+		if len(msgs) != len(validationErrors) {
+			// custom messages that do not map to fields are kept as is
+			return &customValidationError{newValidationError(validationErrors, nil), strings.Join(msgs, ", ")}
 		}
-		return errors.New(errorMsg)
+		return newValidationError(validationErrors, msgs)
 	}
-	return err
+
+	return newValidationError(validationErrors, nil)
+}
+
+type customValidationError struct {
+	*ValidationError
+	message string
+}
+
+func (e *customValidationError) Error() string {
+	return e.message
+}
+
+func (e *customValidationError) Unwrap() error {
+	return e.ValidationError
 }
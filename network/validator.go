@@ -0,0 +1,130 @@
+package network
+
+import (
+	"regexp"
+	"strconv"
+	"sync"
+	"unicode"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var sharedValidator struct {
+	once sync.Once
+	v    *validator.Validate
+}
+
+// Validator is the instance ValidateDto checks validate tags with. It is
+// created once so the struct cache survives between requests. The custom
+// tags of this package are registered on it and on gin's validator, which
+// checks the binding tags and names fields the same way:
+//
+//   - mongoid: a hex ObjectID
+//   - slug: lower case words joined by dashes
+//   - strongpassword: 8 characters, or the param, with upper and lower
+//     case letters, a number and a symbol
+//   - filesize, maxfiles, mimetype: see ReqMultipart
+func Validator() *validator.Validate {
+	sharedValidator.once.Do(func() {
+		v := validator.New()
+		v.RegisterTagNameFunc(CustomTagNameFunc())
+		registerValidations(v)
+		sharedValidator.v = v
+		if gv := bindingValidator(); gv != nil {
+			gv.RegisterTagNameFunc(CustomTagNameFunc())
+			registerValidations(gv)
+		}
+	})
+	return sharedValidator.v
+}
+
+// bindingValidator is gin's validator, nil when binding.Validator was
+// replaced by one not built on validator.Validate.
+func bindingValidator() *validator.Validate {
+	gv, _ := binding.Validator.Engine().(*validator.Validate)
+	return gv
+}
+
+// validators are the shared instance and gin's so registered tags work
+// with validate and binding tags alike.
+func validators() []*validator.Validate {
+	vs := []*validator.Validate{Validator()}
+	if gv := bindingValidator(); gv != nil && gv != vs[0] {
+		vs = append(vs, gv)
+	}
+	return vs
+}
+
+// RegisterValidation adds a custom tag. Like all registrations it is not
+// safe for concurrent use, so register tags before serving. It only fails
+// for an empty tag or fn, so either both validators get the tag or none.
+func RegisterValidation(tag string, fn validator.Func, callValidationEvenIfNull ...bool) error {
+	for _, v := range validators() {
+		if err := v.RegisterValidation(tag, fn, callValidationEvenIfNull...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterStructValidation adds a validation across the fields of types.
+func RegisterStructValidation(fn validator.StructLevelFunc, types ...any) {
+	for _, v := range validators() {
+		v.RegisterStructValidation(fn, types...)
+	}
+}
+
+// RegisterAlias names a set of tags, e.g. RegisterAlias("username", "min=3,max=20,alphanum").
+func RegisterAlias(alias string, tags string) {
+	for _, v := range validators() {
+		v.RegisterAlias(alias, tags)
+	}
+}
+
+func registerValidations(v *validator.Validate) {
+	v.RegisterValidation("mongoid", validateMongoId)
+	v.RegisterValidation("slug", validateSlug)
+	v.RegisterValidation("strongpassword", validateStrongPassword)
+	registerFileValidations(v)
+}
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+func validateMongoId(fl validator.FieldLevel) bool {
+	return primitive.IsValidObjectID(fl.Field().String())
+}
+
+func validateSlug(fl validator.FieldLevel) bool {
+	return slugPattern.MatchString(fl.Field().String())
+}
+
+func validateStrongPassword(fl validator.FieldLevel) bool {
+	minLength := 8
+	if param := fl.Param(); param != "" {
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			panic("invalid strongpassword length " + strconv.Quote(param))
+		}
+		minLength = n
+	}
+
+	password := fl.Field().String()
+	var upper, lower, digit, symbol bool
+	length := 0
+	for _, r := range password {
+		length++
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	return length >= minLength && upper && lower && digit && symbol
+}
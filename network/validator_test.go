@@ -0,0 +1,130 @@
+package network
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type builtinPayload struct {
+	Id       string `json:"id" validate:"mongoid"`
+	Slug     string `json:"slug" validate:"slug"`
+	Password string `json:"password" validate:"strongpassword=10"`
+	Phone    string `json:"phone" validate:"e164"`
+	Zone     string `json:"zone" validate:"timezone"`
+}
+
+func failedTags(t *testing.T, err error) []string {
+	t.Helper()
+	var validationError *ValidationError
+	if !assert.ErrorAs(t, err, &validationError) {
+		return nil
+	}
+	var tags []string
+	for _, e := range validationError.Errors {
+		tags = append(tags, e.Field+":"+e.Tag)
+	}
+	return tags
+}
+
+func TestValidator_Shared(t *testing.T) {
+	assert.Same(t, Validator(), Validator())
+}
+
+type profilePayload struct {
+	FullName string `json:"full_name" binding:"required"`
+}
+
+func TestValidator_BindingTags(t *testing.T) {
+	Validator()
+	assert.NotSame(t, Validator(), binding.Validator.Engine())
+
+	mockHandler := func(ctx *gin.Context) {
+		_, err := ReqBody[profilePayload](ctx)
+		assert.Equal(t, []string{"full_name:required"}, failedTags(t, err))
+	}
+
+	MockTestHandler(t, "POST", "/mock", "/mock", `{}`, mockHandler, nil)
+}
+
+func TestValidator_BuiltinTags(t *testing.T) {
+	_, err := ValidateDto(&builtinPayload{
+		Id:       "65f1c2a4e1b2c3d4e5f6a7b8",
+		Slug:     "hello-world-2",
+		Password: "Str0ng!Pass",
+		Phone:    "+14155552671",
+		Zone:     "Europe/Berlin",
+	})
+	assert.NoError(t, err)
+
+	_, err = ValidateDto(&builtinPayload{
+		Id:       "123",
+		Slug:     "Hello World",
+		Password: "Weak1!",
+		Phone:    "555",
+		Zone:     "Mars/Base",
+	})
+	assert.ElementsMatch(t, []string{"id:mongoid", "slug:slug", "password:strongpassword", "phone:e164", "zone:timezone"}, failedTags(t, err))
+	assert.Contains(t, err.Error(), "slug must contain only lowercase letters, numbers and dashes")
+}
+
+func TestValidateStrongPassword(t *testing.T) {
+	v := validator.New()
+	v.RegisterValidation("strongpassword", validateStrongPassword)
+
+	assert.NoError(t, v.Var("Abcdef1!", "strongpassword"))
+	assert.Error(t, v.Var("abcdef1!", "strongpassword"))
+	assert.Error(t, v.Var("ABCDEF1!", "strongpassword"))
+	assert.Error(t, v.Var("Abcdefg!", "strongpassword"))
+	assert.Error(t, v.Var("Abcdefg1", "strongpassword"))
+	assert.Error(t, v.Var("Ab1!", "strongpassword"))
+}
+
+type teamPayload struct {
+	Handle string `json:"handle" binding:"required,teamhandle" validate:"teamhandle"`
+	Name   string `json:"name" validate:"teamname"`
+	Min    int    `json:"min"`
+	Max    int    `json:"max"`
+}
+
+func TestValidator_Registry(t *testing.T) {
+	r := NewRouterWithConfig(RouterConfig{Mode: gin.TestMode, Logger: logger.Nop()})
+	assert.NoError(t, r.RegisterValidation("teamhandle", func(fl validator.FieldLevel) bool {
+		return strings.HasPrefix(fl.Field().String(), "@")
+	}))
+	r.RegisterAlias("teamname", "min=3,max=10")
+	r.RegisterStructValidation(func(sl validator.StructLevel) {
+		p := sl.Current().Interface().(teamPayload)
+		if p.Min > p.Max {
+			sl.ReportError(p.Min, "min", "Min", "ltefield", "max")
+		}
+	}, teamPayload{})
+
+	_, err := ValidateDto(&teamPayload{Handle: "@team", Name: "core", Min: 1, Max: 2})
+	assert.NoError(t, err)
+
+	_, err = ValidateDto(&teamPayload{Handle: "team", Name: "x", Min: 3, Max: 2})
+	assert.ElementsMatch(t, []string{"handle:teamhandle", "name:teamname", "min:ltefield"}, failedTags(t, err))
+
+	// gin binding checks the binding tag with the registered tag too
+	r.GetEngine().POST("/teams", func(ctx *gin.Context) {
+		if _, err := ReqBody[teamPayload](ctx); err != nil {
+			SendBadRequestError(ctx, err.Error(), err)
+			return
+		}
+		SendSuccessMsgResponse(ctx, "ok")
+	})
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/teams", strings.NewReader(`{"handle":"team","name":"core","min":1,"max":2}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.GetEngine().ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"tag":"teamhandle"`)
+}
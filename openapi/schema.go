@@ -0,0 +1,383 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Schema is a JSON Schema (draft 2020-12) as used by OpenAPI 3.1.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int64             `json:"minLength,omitempty"`
+	MaxLength            *int64             `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinItems             *int64             `json:"minItems,omitempty"`
+	MaxItems             *int64             `json:"maxItems,omitempty"`
+}
+
+const (
+	InQuery  = "query"
+	InPath   = "path"
+	InHeader = "header"
+)
+
+const refPrefix = "#/components/schemas/"
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	objectIdType = reflect.TypeOf(primitive.ObjectID{})
+	uuidType     = reflect.TypeOf(uuid.UUID{})
+	rawJSONType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// Schemas derives schemas from Go types, keeping named structs as
+// components referenced by $ref. Field names come from the json tag and
+// constraints from the validate and binding tags.
+type Schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func NewSchemas() *Schemas {
+	return &Schemas{
+		components: make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+func (s *Schemas) Components() map[string]*Schema {
+	return s.components
+}
+
+// Component returns the registered schema behind a $ref schema.
+func (s *Schemas) Component(ref *Schema) *Schema {
+	if ref == nil || !strings.HasPrefix(ref.Ref, refPrefix) {
+		return ref
+	}
+	return s.components[strings.TrimPrefix(ref.Ref, refPrefix)]
+}
+
+func (s *Schemas) Schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case objectIdType:
+		return &Schema{Type: "string", Pattern: "^[0-9a-fA-F]{24}$"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawJSONType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.Schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.Schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		return s.ref(t)
+	}
+	// interfaces and anything else accept any value
+	return &Schema{}
+}
+
+func (s *Schemas) ref(t reflect.Type) *Schema {
+	name, ok := s.names[t]
+	if !ok {
+		name = s.componentName(t)
+		s.names[t] = name
+		// registered before the fields so recursive types terminate
+		schema := &Schema{}
+		s.components[name] = schema
+		*schema = *s.structSchema(t)
+	}
+	return &Schema{Ref: refPrefix + name}
+}
+
+// componentName turns Paginated[github.com/x/dto.Item] into Paginated_Item
+// and numbers types of other packages sharing a name.
+func (s *Schemas) componentName(t reflect.Type) string {
+	name := t.Name()
+	if i := strings.Index(name, "["); i >= 0 {
+		args := strings.Split(strings.TrimSuffix(name[i+1:], "]"), ",")
+		for j, arg := range args {
+			arg = arg[strings.LastIndex(arg, "/")+1:]
+			args[j] = strings.NewReplacer(".", "_", "*", "", "[", "_", "]", "").Replace(arg[strings.Index(arg, ".")+1:])
+		}
+		name = name[:i] + "_" + strings.Join(args, "_")
+	}
+
+	unique := name
+	for n := 2; ; n++ {
+		if _, taken := s.components[unique]; !taken {
+			return unique
+		}
+		unique = name + strconv.Itoa(n)
+	}
+}
+
+func (s *Schemas) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	eachField(t, "json", func(name string, field reflect.StructField) {
+		if isParam(field) {
+			return
+		}
+		prop := s.Schema(field.Type)
+		if applyRules(prop, field) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = prop
+	})
+	return schema
+}
+
+// HasBody reports whether struct t has fields bound from the body, the
+// ones not bound from a param only.
+func HasBody(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	found := false
+	eachField(t, "json", func(name string, field reflect.StructField) {
+		found = found || !isParam(field)
+	})
+	return found
+}
+
+// isParam reports fields tagged for a param but not for json, like the
+// uri bound id of a combined request struct.
+func isParam(field reflect.StructField) bool {
+	if _, ok := field.Tag.Lookup("json"); ok {
+		return false
+	}
+	for _, tag := range []string{"uri", "form", "header"} {
+		if name, ok := field.Tag.Lookup(tag); ok && name != "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// Parameters lists the fields of struct t as parameters located in, named
+// by the tag gin binds them with: form for query, uri for path and header.
+// Fields without the tag are left out.
+func (s *Schemas) Parameters(t reflect.Type, in string) []*Parameter {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	tag := map[string]string{InQuery: "form", InPath: "uri", InHeader: "header"}[in]
+	var params []*Parameter
+	eachField(t, tag, func(name string, field reflect.StructField) {
+		if _, ok := field.Tag.Lookup(tag); !ok {
+			return
+		}
+		schema := s.Schema(field.Type)
+		required := applyRules(schema, field)
+		params = append(params, &Parameter{
+			Name:     name,
+			In:       in,
+			Required: required || in == InPath,
+			Schema:   schema,
+		})
+	})
+	return params
+}
+
+// eachField walks the exported fields named by tag, flattening embedded
+// structs without a tag name like encoding/json does.
+func eachField(t reflect.Type, tag string, fn func(name string, field reflect.StructField)) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				eachField(ft, tag, fn)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fn(name, field)
+	}
+}
+
+// applyRules maps the validate rules of field onto schema and reports
+// whether the field is required. Rules after dive apply to the items.
+func applyRules(schema *Schema, field reflect.StructField) bool {
+	required := false
+	for _, rule := range strings.Split(field.Tag.Get("binding"), ",") {
+		if rule == "required" {
+			required = true
+		}
+	}
+
+	target := schema
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch {
+		case name == "required" && target == schema:
+			required = true
+		case name == "dive":
+			if target.Items == nil {
+				return required
+			}
+			target = target.Items
+		case strings.Contains(rule, "|"):
+			// alternatives can not be expressed as a single constraint
+		default:
+			applyRule(target, name, param)
+		}
+	}
+	return required
+}
+
+func applyRule(schema *Schema, name string, param string) {
+	if schema.Ref != "" {
+		return
+	}
+
+	switch name {
+	case "min", "gte":
+		setBound(schema, param, 0, true)
+	case "max", "lte":
+		setBound(schema, param, 0, false)
+	case "gt":
+		setBound(schema, param, 1, true)
+	case "lt":
+		setBound(schema, param, -1, false)
+	case "len":
+		setBound(schema, param, 0, true)
+		setBound(schema, param, 0, false)
+	case "oneof":
+		for _, v := range strings.Fields(param) {
+			schema.Enum = append(schema.Enum, enumValue(schema.Type, v))
+		}
+	case "email":
+		schema.Format = "email"
+	case "uuid", "uuid3", "uuid4", "uuid5", "uuid_rfc4122":
+		schema.Format = "uuid"
+	case "url", "uri", "http_url":
+		schema.Format = "uri"
+	case "ipv4", "ipv6", "hostname":
+		schema.Format = name
+	case "mongodb":
+		schema.Pattern = "^[0-9a-fA-F]{24}$"
+	}
+}
+
+// setBound applies a min (lower) or max bound. Lengths are whole numbers,
+// so the exclusive gt/lt on strings and arrays become offset inclusive
+// bounds while numbers use the exclusive keywords.
+func setBound(schema *Schema, param string, offset int64, lower bool) {
+	switch schema.Type {
+	case "integer", "number":
+		v, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+		switch {
+		case offset != 0 && lower:
+			schema.ExclusiveMinimum = &v
+		case offset != 0:
+			schema.ExclusiveMaximum = &v
+		case lower:
+			schema.Minimum = &v
+		default:
+			schema.Maximum = &v
+		}
+	case "string", "array":
+		v, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return
+		}
+		v += offset
+		switch {
+		case schema.Type == "string" && lower:
+			schema.MinLength = &v
+		case schema.Type == "string":
+			schema.MaxLength = &v
+		case lower:
+			schema.MinItems = &v
+		default:
+			schema.MaxItems = &v
+		}
+	}
+}
+
+func enumValue(schemaType string, v string) any {
+	switch schemaType {
+	case "integer":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return strings.Trim(v, "'")
+}
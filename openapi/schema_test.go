@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type base struct {
+	Id        primitive.ObjectID `json:"_id"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
+
+type author struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type blog struct {
+	base
+	Title    string            `json:"title" validate:"required,min=3,max=100"`
+	Status   string            `json:"status" validate:"oneof=draft published"`
+	Email    string            `json:"email,omitempty" validate:"omitempty,email"`
+	Ref      uuid.UUID         `json:"ref"`
+	Score    float64           `json:"score" validate:"gt=0,lte=5"`
+	Priority int               `json:"priority" validate:"oneof=1 2 3"`
+	Tags     []string          `json:"tags" validate:"max=5,dive,len=4"`
+	Author   *author           `json:"author" binding:"required"`
+	Meta     map[string]string `json:"meta"`
+	Related  []*blog           `json:"related,omitempty"`
+	Secret   string            `json:"-"`
+	internal string
+}
+
+type page[T any] struct {
+	Items []*T `json:"items"`
+}
+
+type query struct {
+	Page  int64  `form:"page" validate:"required,min=1"`
+	Order string `form:"order" validate:"oneof=asc desc"`
+}
+
+func TestSchemas_Struct(t *testing.T) {
+	s := NewSchemas()
+	ref := s.Schema(reflect.TypeOf(&blog{}))
+	assert.Equal(t, "#/components/schemas/blog", ref.Ref)
+
+	schema := s.Component(ref)
+	assert.Equal(t, "object", schema.Type)
+	assert.ElementsMatch(t, []string{"title", "author"}, schema.Required)
+	assert.NotContains(t, schema.Properties, "Secret")
+	assert.NotContains(t, schema.Properties, "internal")
+
+	p := schema.Properties
+	assert.Equal(t, "^[0-9a-fA-F]{24}$", p["_id"].Pattern)
+	assert.Equal(t, "date-time", p["createdAt"].Format)
+	assert.Equal(t, int64(3), *p["title"].MinLength)
+	assert.Equal(t, int64(100), *p["title"].MaxLength)
+	assert.Equal(t, []any{"draft", "published"}, p["status"].Enum)
+	assert.Equal(t, "email", p["email"].Format)
+	assert.Equal(t, "uuid", p["ref"].Format)
+	assert.Equal(t, 0.0, *p["score"].ExclusiveMinimum)
+	assert.Equal(t, 5.0, *p["score"].Maximum)
+	assert.Equal(t, []any{int64(1), int64(2), int64(3)}, p["priority"].Enum)
+	assert.Equal(t, int64(5), *p["tags"].MaxItems)
+	assert.Equal(t, int64(4), *p["tags"].Items.MinLength)
+	assert.Equal(t, "#/components/schemas/author", p["author"].Ref)
+	assert.Equal(t, "string", p["meta"].AdditionalProperties.Type)
+	assert.Equal(t, "#/components/schemas/blog", p["related"].Items.Ref)
+
+	assert.Equal(t, []string{"name"}, s.Components()["author"].Required)
+}
+
+func TestSchemas_GenericName(t *testing.T) {
+	s := NewSchemas()
+	ref := s.Schema(reflect.TypeOf(page[author]{}))
+	assert.Equal(t, "#/components/schemas/page_author", ref.Ref)
+}
+
+func TestSchemas_Parameters(t *testing.T) {
+	params := NewSchemas().Parameters(reflect.TypeOf(query{}), InQuery)
+
+	assert.Len(t, params, 2)
+	assert.Equal(t, "page", params[0].Name)
+	assert.Equal(t, InQuery, params[0].In)
+	assert.True(t, params[0].Required)
+	assert.Equal(t, 1.0, *params[0].Schema.Minimum)
+	assert.False(t, params[1].Required)
+	assert.Equal(t, []any{"asc", "desc"}, params[1].Schema.Enum)
+}
+
+func TestSchema_Marshal(t *testing.T) {
+	data, err := json.Marshal(NewSchemas().Schema(reflect.TypeOf(author{})))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"$ref":"#/components/schemas/author"}`, string(data))
+}
+
+type combined struct {
+	Id    string `uri:"id" validate:"required"`
+	Page  int    `form:"page"`
+	Title string `json:"title"`
+	Note  string
+}
+
+func TestSchemas_CombinedStruct(t *testing.T) {
+	s := NewSchemas()
+	schema := s.Component(s.Schema(reflect.TypeOf(combined{})))
+	assert.ElementsMatch(t, []string{"title", "Note"}, keys(schema.Properties))
+
+	params := s.Parameters(reflect.TypeOf(combined{}), InPath)
+	assert.Len(t, params, 1)
+	assert.Equal(t, "id", params[0].Name)
+	assert.True(t, params[0].Required)
+
+	assert.True(t, HasBody(reflect.TypeOf(&combined{})))
+	assert.False(t, HasBody(reflect.TypeOf(query{})))
+}
+
+func keys[V any](m map[string]V) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
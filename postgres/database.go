@@ -4,11 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"os"
 	"time"
 
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/metrics"
+	"github.com/afteracademy/goserve/v2/tracing"
+	"github.com/afteracademy/goserve/v2/utility"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type DbConfig struct {
@@ -21,12 +26,26 @@ type DbConfig struct {
 	MaxPoolSize uint16
 	Timeout     time.Duration
 	SSLMode     string
+	Retry       utility.RetryConfig
+	Logger      logger.Logger
+	// TracerProvider enables spans for queries run through the pool when set.
+	TracerProvider trace.TracerProvider
+	// Metrics exposes the connection pool stats when set.
+	Metrics *metrics.Metrics
 }
 
+var (
+	ErrConfig  = errors.New("failed to parse postgres config")
+	ErrConnect = errors.New("connection to postgres failed")
+	ErrPing    = errors.New("pinging postgres failed")
+)
+
 type Database interface {
 	GetInstance() *database
 	Connect()
+	ConnectContext(ctx context.Context) error
 	Disconnect()
+	DisconnectContext(ctx context.Context) error
 	Pool() *pgxpool.Pool
 }
 
@@ -34,12 +53,14 @@ type database struct {
 	pool    *pgxpool.Pool
 	context context.Context
 	config  DbConfig
+	logger  logger.Logger
 }
 
 func NewDatabase(ctx context.Context, config DbConfig) Database {
 	return &database{
 		context: ctx,
 		config:  config,
+		logger:  logger.Or(config.Logger),
 	}
 }
 
@@ -52,6 +73,13 @@ func (db *database) Pool() *pgxpool.Pool {
 }
 
 func (db *database) Connect() {
+	if err := db.ConnectContext(db.context); err != nil {
+		db.logger.Error("connection to postgres failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func (db *database) ConnectContext(ctx context.Context) error {
 	dsn := fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
 		db.config.User,
@@ -64,37 +92,81 @@ func (db *database) Connect() {
 
 	cfg, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
-		log.Fatal("failed to parse postgres config:", err)
+		return fmt.Errorf("%w: %w", ErrConfig, err)
 	}
 
 	cfg.MinConns = int32(db.config.MinPoolSize)
 	cfg.MaxConns = int32(db.config.MaxPoolSize)
 	cfg.MaxConnLifetime = time.Hour
 	cfg.HealthCheckPeriod = time.Minute
+	if db.config.TracerProvider != nil {
+		cfg.ConnConfig.Tracer = newQueryTracer(tracing.Tracer(db.config.TracerProvider), db.config.Name)
+	}
 
-	ctx, cancel := context.WithTimeout(db.context, db.config.Timeout)
-	defer cancel()
-
-	fmt.Println("connecting postgres...")
-	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	db.logger.Info("connecting postgres", "host", db.config.Host, "port", db.config.Port, "database", db.config.Name)
+	var pool *pgxpool.Pool
+	err = utility.Retry(ctx, db.config.Retry, func(ctx context.Context) error {
+		ctx, cancel := db.withTimeout(ctx)
+		defer cancel()
+
+		p, err := pgxpool.NewWithConfig(ctx, cfg)
+		if err != nil {
+			db.logger.Warn("postgres connection attempt failed", "error", err)
+			return fmt.Errorf("%w: %w", ErrConnect, err)
+		}
+
+		if err := p.Ping(ctx); err != nil {
+			p.Close()
+			db.logger.Warn("postgres ping attempt failed", "error", err)
+			return fmt.Errorf("%w: %w", ErrPing, err)
+		}
+
+		pool = p
+		return nil
+	})
 	if err != nil {
-		log.Fatal("connection to postgres failed:", err)
+		return err
 	}
 
-	if err := pool.Ping(ctx); err != nil {
-		log.Fatal("pinging postgres failed:", err)
+	db.logger.Info("connected to postgres", "database", db.config.Name)
+	db.pool = pool
+
+	if db.config.Metrics != nil {
+		if err := db.config.Metrics.RegisterPool("postgresql", db.poolStats); err != nil {
+			db.logger.Warn("registering postgres pool metrics failed", "error", err)
+		}
 	}
+	return nil
+}
 
-	fmt.Println("connected to postgres!")
-	db.pool = pool
+func (db *database) poolStats() metrics.PoolStats {
+	stat := db.pool.Stat()
+	return metrics.PoolStats{
+		Open:  int64(stat.TotalConns()),
+		InUse: int64(stat.AcquiredConns()),
+		Idle:  int64(stat.IdleConns()),
+		Max:   int64(stat.MaxConns()),
+	}
 }
 
 func (db *database) Disconnect() {
-	fmt.Println("disconnecting postgres...")
+	db.DisconnectContext(db.context)
+}
+
+func (db *database) DisconnectContext(ctx context.Context) error {
+	db.logger.Info("disconnecting postgres")
 	if db.pool != nil {
 		db.pool.Close()
 	}
-	fmt.Println("disconnected postgres")
+	db.logger.Info("disconnected postgres")
+	return nil
+}
+
+func (db *database) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if db.config.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, db.config.Timeout)
 }
 
 func ParseUUID(id string) (uuid.UUID, error) {
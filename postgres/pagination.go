@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrInvalidPosition = errors.New("invalid keyset position")
+	ErrInvalidPage     = errors.New("page and limit must be at least 1")
+)
+
+// Querier is satisfied by *pgxpool.Pool, *pgx.Conn and pgx.Tx.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// FindPaginatedWithCount runs query for a single page and counts all rows it
+// matches. query must be a SELECT without LIMIT/OFFSET; rows are scanned
+// into T by column name.
+func FindPaginatedWithCount[T any](ctx context.Context, q Querier, query string, page int64, limit int64, args ...any) ([]*T, int64, error) {
+	if page < 1 || limit < 1 {
+		return nil, 0, ErrInvalidPage
+	}
+
+	var total int64
+	if err := q.QueryRow(ctx, countSQL(query), args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("error counting rows: %w", err)
+	}
+
+	pageArgs := append(args[:len(args):len(args)], limit, (page-1)*limit)
+	rows, err := q.Query(ctx, pageSQL(query, len(args)), pageArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error executing query: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[T])
+	if err != nil {
+		return nil, 0, fmt.Errorf("error scanning rows: %w", err)
+	}
+	return items, total, nil
+}
+
+func countSQL(query string) string {
+	return fmt.Sprintf("SELECT count(*) FROM (%s) AS paginated", query)
+}
+
+func pageSQL(query string, nargs int) string {
+	return fmt.Sprintf("%s LIMIT $%d OFFSET $%d", query, nargs+1, nargs+2)
+}
+
+// Keyset describes the ordering FindAfter pages by. Column may be empty to
+// page by IdColumn ("id" by default) alone; both must be non null and indexed.
+type Keyset struct {
+	Column   string
+	IdColumn string
+	Desc     bool
+}
+
+func (k Keyset) columns() []string {
+	if k.Column == "" {
+		return []string{k.IdColumn}
+	}
+	return []string{k.Column, k.IdColumn}
+}
+
+// FindAfter returns up to limit rows of query following the position after,
+// in keyset order. query must be a SELECT without ORDER BY/LIMIT that
+// includes the keyset columns. The returned position is nil on the last
+// page, otherwise it is passed as after to get the next page.
+func FindAfter[T any](ctx context.Context, q Querier, query string, keyset Keyset, after []byte, limit int64, args ...any) ([]*T, []byte, error) {
+	if limit < 1 {
+		return nil, nil, ErrInvalidPage
+	}
+	if keyset.IdColumn == "" {
+		keyset.IdColumn = "id"
+	}
+	columns := keyset.columns()
+
+	nargs := len(args)
+	// capped so appending never writes into the caller's backing array
+	args = args[:nargs:nargs]
+	if after != nil {
+		// positions hold the text form of the values, which postgres
+		// parses back into the column types
+		var position []string
+		if err := json.Unmarshal(after, &position); err != nil || len(position) != len(columns) {
+			return nil, nil, ErrInvalidPosition
+		}
+		for _, p := range position {
+			args = append(args, p)
+		}
+	}
+	args = append(args, limit+1)
+
+	rows, err := q.Query(ctx, keysetSQL(query, keyset, nargs, after != nil), args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing query: %w", err)
+	}
+
+	var fields []pgconn.FieldDescription
+	var indexes []int
+	var positions [][]any
+	items, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*T, error) {
+		if indexes == nil {
+			fields = row.FieldDescriptions()
+			idx, err := columnIndexes(fields, columns)
+			if err != nil {
+				return nil, err
+			}
+			indexes = idx
+		}
+		values, err := row.Values()
+		if err != nil {
+			return nil, err
+		}
+		position := make([]any, len(indexes))
+		for i, idx := range indexes {
+			position[i] = values[idx]
+		}
+		positions = append(positions, position)
+		return pgx.RowToAddrOfStructByName[T](row)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error scanning rows: %w", err)
+	}
+
+	if int64(len(items)) <= limit {
+		return items, nil, nil
+	}
+
+	next, err := encodePosition(fields, indexes, positions[limit-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	return items[:limit], next, nil
+}
+
+func keysetSQL(query string, keyset Keyset, nargs int, after bool) string {
+	columns := make([]string, 0, 2)
+	for _, c := range keyset.columns() {
+		columns = append(columns, "keyset."+pgx.Identifier{c}.Sanitize())
+	}
+
+	var sql strings.Builder
+	fmt.Fprintf(&sql, "SELECT * FROM (%s) AS keyset", query)
+
+	if after {
+		op := ">"
+		if keyset.Desc {
+			op = "<"
+		}
+		params := make([]string, len(columns))
+		for i := range columns {
+			params[i] = fmt.Sprintf("$%d", nargs+i+1)
+		}
+		nargs += len(columns)
+		fmt.Fprintf(&sql, " WHERE (%s) %s (%s)", strings.Join(columns, ", "), op, strings.Join(params, ", "))
+	}
+
+	direction := " ASC"
+	if keyset.Desc {
+		direction = " DESC"
+	}
+	fmt.Fprintf(&sql, " ORDER BY %s LIMIT $%d", strings.Join(columns, direction+", ")+direction, nargs+1)
+	return sql.String()
+}
+
+func columnIndexes(fields []pgconn.FieldDescription, columns []string) ([]int, error) {
+	indexes := make([]int, len(columns))
+	for i, c := range columns {
+		indexes[i] = -1
+		for j, f := range fields {
+			if f.Name == c {
+				indexes[i] = j
+				break
+			}
+		}
+		if indexes[i] < 0 {
+			return nil, fmt.Errorf("keyset column %s not selected", c)
+		}
+	}
+	return indexes, nil
+}
+
+func encodePosition(fields []pgconn.FieldDescription, indexes []int, values []any) ([]byte, error) {
+	typeMap := pgtype.NewMap()
+	position := make([]string, len(values))
+	for i, v := range values {
+		text, err := typeMap.Encode(fields[indexes[i]].DataTypeOID, pgtype.TextFormatCode, v, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding keyset position: %w", err)
+		}
+		position[i] = string(text)
+	}
+	return json.Marshal(position)
+}
@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(dest ...any) error {
+	return r.err
+}
+
+type countRow struct {
+	total int64
+}
+
+func (r countRow) Scan(dest ...any) error {
+	*dest[0].(*int64) = r.total
+	return nil
+}
+
+// fakeRows returns values for fields, scanning them as they are.
+type fakeRows struct {
+	fields []pgconn.FieldDescription
+	values [][]any
+	row    int
+}
+
+func (r *fakeRows) Close()                                       {}
+func (r *fakeRows) Err() error                                   { return nil }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return r.fields }
+func (r *fakeRows) RawValues() [][]byte                          { return nil }
+func (r *fakeRows) Conn() *pgx.Conn                              { return nil }
+
+func (r *fakeRows) Next() bool {
+	r.row++
+	return r.row <= len(r.values)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(r.values[r.row-1][i]))
+	}
+	return nil
+}
+
+func (r *fakeRows) Values() ([]any, error) {
+	return r.values[r.row-1], nil
+}
+
+type fakeQuerier struct {
+	queries []string
+	args    [][]any
+	err     error
+	rows    pgx.Rows
+	// total makes the count query succeed
+	total *int64
+}
+
+func (q *fakeQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	q.queries = append(q.queries, sql)
+	q.args = append(q.args, args)
+	if q.rows != nil {
+		return q.rows, nil
+	}
+	return nil, q.err
+}
+
+func (q *fakeQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	q.queries = append(q.queries, sql)
+	q.args = append(q.args, args)
+	if q.total != nil {
+		return countRow{*q.total}
+	}
+	return errRow{q.err}
+}
+
+type blog struct {
+	Id    string
+	Title string
+}
+
+func TestFindPaginatedWithCount_Errors(t *testing.T) {
+	q := &fakeQuerier{err: errors.New("connection refused")}
+
+	_, _, err := FindPaginatedWithCount[blog](context.Background(), q, "SELECT id, title FROM blogs WHERE author = $1", 2, 10, "ann")
+	assert.ErrorIs(t, err, q.err)
+	assert.Equal(t, []string{"SELECT count(*) FROM (SELECT id, title FROM blogs WHERE author = $1) AS paginated"}, q.queries)
+	assert.Equal(t, []any{"ann"}, q.args[0])
+}
+
+func TestFindPaginatedWithCount_KeepsCallerArgs(t *testing.T) {
+	total := int64(30)
+	q := &fakeQuerier{err: errors.New("connection refused"), total: &total}
+
+	args := make([]any, 1, 4)
+	args[0] = "ann"
+	_, _, err := FindPaginatedWithCount[blog](context.Background(), q, "SELECT * FROM blogs WHERE author = $1", 3, 10, args...)
+	assert.ErrorIs(t, err, q.err)
+	assert.Equal(t, []any{"ann", int64(10), int64(20)}, q.args[1])
+	assert.Nil(t, args[:2][1], "caller backing array must not be written")
+}
+
+func TestFindPaginatedWithCount_InvalidPage(t *testing.T) {
+	q := &fakeQuerier{}
+	for _, pl := range [][2]int64{{0, 10}, {-1, 10}, {1, 0}, {1, -5}} {
+		_, _, err := FindPaginatedWithCount[blog](context.Background(), q, "SELECT * FROM blogs", pl[0], pl[1])
+		assert.ErrorIs(t, err, ErrInvalidPage)
+	}
+	assert.Empty(t, q.queries)
+}
+
+func TestPageSQL(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM blogs LIMIT $1 OFFSET $2", pageSQL("SELECT * FROM blogs", 0))
+	assert.Equal(t, "SELECT * FROM blogs WHERE a = $1 LIMIT $2 OFFSET $3", pageSQL("SELECT * FROM blogs WHERE a = $1", 1))
+}
+
+func TestKeysetSQL(t *testing.T) {
+	assert.Equal(t,
+		`SELECT * FROM (SELECT * FROM blogs) AS keyset ORDER BY keyset."id" ASC LIMIT $1`,
+		keysetSQL("SELECT * FROM blogs", Keyset{IdColumn: "id"}, 0, false))
+
+	assert.Equal(t,
+		`SELECT * FROM (SELECT * FROM blogs WHERE author = $1) AS keyset `+
+			`WHERE (keyset."created_at", keyset."id") < ($2, $3) `+
+			`ORDER BY keyset."created_at" DESC, keyset."id" DESC LIMIT $4`,
+		keysetSQL("SELECT * FROM blogs WHERE author = $1", Keyset{Column: "created_at", IdColumn: "id", Desc: true}, 1, true))
+}
+
+func TestEncodePosition(t *testing.T) {
+	fields := []pgconn.FieldDescription{
+		{Name: "id", DataTypeOID: pgtype.Int8OID},
+		{Name: "created_at", DataTypeOID: pgtype.TimestamptzOID},
+	}
+
+	indexes, err := columnIndexes(fields, []string{"created_at", "id"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 0}, indexes)
+
+	_, err = columnIndexes(fields, []string{"title"})
+	assert.Error(t, err)
+
+	createdAt := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	position, err := encodePosition(fields, indexes, []any{createdAt, int64(42)})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `["2024-05-01 10:30:00Z", "42"]`, string(position))
+}
+
+func TestFindAfter_InvalidPosition(t *testing.T) {
+	q := &fakeQuerier{}
+	_, _, err := FindAfter[blog](context.Background(), q, "SELECT * FROM blogs", Keyset{}, []byte(`["1", "2"]`), 10)
+	assert.ErrorIs(t, err, ErrInvalidPosition)
+	assert.Empty(t, q.queries)
+}
+
+func TestFindAfter_InvalidLimit(t *testing.T) {
+	q := &fakeQuerier{}
+	_, _, err := FindAfter[blog](context.Background(), q, "SELECT * FROM blogs", Keyset{}, nil, 0)
+	assert.ErrorIs(t, err, ErrInvalidPage)
+	assert.Empty(t, q.queries)
+}
+
+func TestFindAfter_KeepsCallerArgs(t *testing.T) {
+	q := &fakeQuerier{err: errors.New("connection refused")}
+	args := make([]any, 1, 4)
+	args[0] = "ann"
+	_, _, err := FindAfter[blog](context.Background(), q, "SELECT * FROM blogs WHERE author = $1",
+		Keyset{}, []byte(`["42"]`), 10, args...)
+	assert.ErrorIs(t, err, q.err)
+	assert.Equal(t, []any{"ann", "42", int64(11)}, q.args[0])
+	assert.Equal(t, []any{"ann", nil, nil}, args[:3])
+}
+
+func TestFindAfter_Args(t *testing.T) {
+	q := &fakeQuerier{err: errors.New("connection refused")}
+	_, _, err := FindAfter[blog](context.Background(), q, "SELECT * FROM blogs WHERE author = $1",
+		Keyset{Column: "created_at"}, []byte(`["2024-05-01 10:30:00Z", "42"]`), 10, "ann")
+	assert.ErrorIs(t, err, q.err)
+	assert.Equal(t, []any{"ann", "2024-05-01 10:30:00Z", "42", int64(11)}, q.args[0])
+}
+
+type post struct {
+	Id        int64
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func TestFindAfter_CursorRoundTrip(t *testing.T) {
+	query := "SELECT id, created_at FROM posts WHERE author = $1"
+	keyset := Keyset{Column: "created_at", Desc: true}
+	createdAt := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	q := &fakeQuerier{rows: &fakeRows{
+		fields: []pgconn.FieldDescription{
+			{Name: "id", DataTypeOID: pgtype.Int8OID},
+			{Name: "created_at", DataTypeOID: pgtype.TimestamptzOID},
+		},
+		values: [][]any{
+			{int64(3), createdAt.Add(time.Hour)},
+			{int64(2), createdAt},
+			{int64(1), createdAt},
+		},
+	}}
+
+	posts, next, err := FindAfter[post](context.Background(), q, query, keyset, nil, 2, "ann")
+	assert.NoError(t, err)
+	assert.Equal(t, []*post{{3, createdAt.Add(time.Hour)}, {2, createdAt}}, posts)
+	assert.Equal(t, []any{"ann", int64(3)}, q.args[0])
+	assert.NotNil(t, next)
+
+	// the next page starts after the last row, ties on created_at broken by id
+	q = &fakeQuerier{err: errors.New("connection refused")}
+	_, _, err = FindAfter[post](context.Background(), q, query, keyset, next, 2, "ann")
+	assert.ErrorIs(t, err, q.err)
+	assert.Equal(t,
+		`SELECT * FROM (SELECT id, created_at FROM posts WHERE author = $1) AS keyset `+
+			`WHERE (keyset."created_at", keyset."id") < ($2, $3) `+
+			`ORDER BY keyset."created_at" DESC, keyset."id" DESC LIMIT $4`,
+		q.queries[0])
+	assert.Equal(t, []any{"ann", "2024-05-01 10:30:00Z", "2", int64(3)}, q.args[0])
+}
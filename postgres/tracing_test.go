@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/afteracademy/goserve/v2/tracing"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestQueryTracer(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := newQueryTracer(tracing.Tracer(tp), "blogs")
+
+	parentCtx, parent := tracing.Tracer(tp).Start(context.Background(), "handler")
+	defer parent.End()
+
+	t.Run("should create child span for query", func(t *testing.T) {
+		exporter.Reset()
+
+		ctx := tracer.TraceQueryStart(parentCtx, nil, pgx.TraceQueryStartData{SQL: "select * from blogs where id = $1"})
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 1)
+		span := spans[0]
+		assert.Equal(t, "postgres SELECT", span.Name)
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+		assert.Contains(t, span.Attributes, attribute.String("db.system", "postgresql"))
+		assert.Contains(t, span.Attributes, attribute.String("db.namespace", "blogs"))
+		assert.Contains(t, span.Attributes, attribute.Int64("db.response.rows_affected", 1))
+	})
+
+	t.Run("should record query error", func(t *testing.T) {
+		exporter.Reset()
+
+		ctx := tracer.TraceQueryStart(parentCtx, nil, pgx.TraceQueryStartData{SQL: "insert into blogs values ($1)"})
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("duplicate key")})
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "postgres INSERT", spans[0].Name)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+	})
+}
@@ -0,0 +1,31 @@
+package ratelimit
+
+import (
+	"github.com/afteracademy/goserve/v2/auth"
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+)
+
+// KeyFunc identifies the client a request is counted against.
+type KeyFunc func(ctx *gin.Context) string
+
+func KeyByIP(ctx *gin.Context) string {
+	return "ip:" + ctx.ClientIP()
+}
+
+// KeyByApiKey counts requests per api key hash, falling back to the client ip.
+func KeyByApiKey(ctx *gin.Context) string {
+	if key := ctx.GetHeader(network.ApiKeyHeader); key != "" {
+		return "apikey:" + auth.HashApiKey(key)
+	}
+	return KeyByIP(ctx)
+}
+
+// KeyByPrincipal counts requests per authenticated principal, falling back
+// to the client ip. It needs to run after the authentication provider.
+func KeyByPrincipal(ctx *gin.Context) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok && principal.Id != "" {
+		return "principal:" + principal.Type + ":" + principal.Id
+	}
+	return KeyByIP(ctx)
+}
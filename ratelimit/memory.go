@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	window time.Duration
+}
+
+// slidingLog keeps the times of the requests allowed within window.
+type slidingLog struct {
+	times  []time.Time
+	window time.Duration
+}
+
+type memoryLimiter struct {
+	algorithm Algorithm
+	mutex     sync.Mutex
+	buckets   map[string]*bucket
+	logs      map[string]*slidingLog
+	swept     time.Time
+	now       func() time.Time
+}
+
+// NewMemoryLimiter keeps state in process. It suits tests and single
+// instance deployments; use NewRedisLimiter when running replicas.
+func NewMemoryLimiter(algorithm Algorithm) Limiter {
+	return &memoryLimiter{
+		algorithm: algorithm,
+		buckets:   make(map[string]*bucket),
+		logs:      make(map[string]*slidingLog),
+		now:       time.Now,
+	}
+}
+
+func (l *memoryLimiter) Allow(ctx context.Context, key string, rate Rate) (*Result, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	l.sweep(now, rate.Window)
+
+	if l.algorithm == SlidingWindow {
+		return l.slidingWindow(key, rate, now), nil
+	}
+	return l.tokenBucket(key, rate, now), nil
+}
+
+func (l *memoryLimiter) tokenBucket(key string, rate Rate, now time.Time) *Result {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rate.Limit), last: now}
+		l.buckets[key] = b
+	}
+	b.window = rate.Window
+
+	refill := now.Sub(b.last).Seconds() * float64(rate.Limit) / rate.Window.Seconds()
+	b.tokens = math.Min(float64(rate.Limit), b.tokens+refill)
+	b.last = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return tokenBucketResult(rate, b.tokens, allowed)
+}
+
+func (l *memoryLimiter) slidingWindow(key string, rate Rate, now time.Time) *Result {
+	sl, ok := l.logs[key]
+	if !ok {
+		sl = &slidingLog{}
+		l.logs[key] = sl
+	}
+	sl.window = rate.Window
+	log := pruneLog(sl.times, now.Add(-rate.Window))
+
+	allowed := len(log) < rate.Limit
+	if allowed {
+		log = append(log, now)
+	}
+	sl.times = log
+
+	oldest, newest := now, now
+	if len(log) > 0 {
+		oldest, newest = log[0], log[len(log)-1]
+	}
+	return slidingWindowResult(rate, len(log), oldest, newest, now, allowed)
+}
+
+func pruneLog(log []time.Time, after time.Time) []time.Time {
+	i := 0
+	for i < len(log) && !log[i].After(after) {
+		i++
+	}
+	return log[i:]
+}
+
+// sweep drops idle keys at most once per window so memory stays bounded
+// by the number of active clients. Keys are idle after their own window,
+// as rates of different windows may share the limiter.
+func (l *memoryLimiter) sweep(now time.Time, window time.Duration) {
+	if now.Sub(l.swept) < window {
+		return
+	}
+	l.swept = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > b.window {
+			delete(l.buckets, key)
+		}
+	}
+	for key, sl := range l.logs {
+		if len(sl.times) == 0 || now.Sub(sl.times[len(sl.times)-1]) > sl.window {
+			delete(l.logs, key)
+		}
+	}
+}
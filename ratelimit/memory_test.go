@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(1_700_000_000, 0)}
+}
+
+func allowN(t *testing.T, l Limiter, key string, rate Rate, n int) []*Result {
+	t.Helper()
+	results := make([]*Result, n)
+	for i := range n {
+		r, err := l.Allow(context.Background(), key, rate)
+		assert.NoError(t, err)
+		results[i] = r
+	}
+	return results
+}
+
+func TestMemoryLimiter_TokenBucket(t *testing.T) {
+	clock := newFakeClock()
+	l := NewMemoryLimiter(TokenBucket).(*memoryLimiter)
+	l.now = clock.Now
+	rate := Rate{Limit: 3, Window: 3 * time.Second}
+
+	results := allowN(t, l, "a", rate, 4)
+	assert.True(t, results[0].Allowed)
+	assert.Equal(t, 2, results[0].Remaining)
+	assert.True(t, results[2].Allowed)
+	assert.Equal(t, 0, results[2].Remaining)
+	assert.False(t, results[3].Allowed)
+	assert.Equal(t, time.Second, results[3].RetryAfter)
+	assert.Equal(t, 3*time.Second, results[3].Reset)
+
+	clock.Advance(time.Second)
+	results = allowN(t, l, "a", rate, 2)
+	assert.True(t, results[0].Allowed)
+	assert.False(t, results[1].Allowed)
+
+	results = allowN(t, l, "b", rate, 1)
+	assert.True(t, results[0].Allowed)
+}
+
+func TestMemoryLimiter_SlidingWindow(t *testing.T) {
+	clock := newFakeClock()
+	l := NewMemoryLimiter(SlidingWindow).(*memoryLimiter)
+	l.now = clock.Now
+	rate := Rate{Limit: 2, Window: time.Minute}
+
+	results := allowN(t, l, "a", rate, 1)
+	assert.True(t, results[0].Allowed)
+	assert.Equal(t, 1, results[0].Remaining)
+
+	clock.Advance(30 * time.Second)
+	results = allowN(t, l, "a", rate, 2)
+	assert.True(t, results[0].Allowed)
+	assert.False(t, results[1].Allowed)
+	assert.Equal(t, 30*time.Second, results[1].RetryAfter)
+	assert.Equal(t, time.Minute, results[1].Reset)
+
+	clock.Advance(31 * time.Second)
+	results = allowN(t, l, "a", rate, 2)
+	assert.True(t, results[0].Allowed)
+	assert.False(t, results[1].Allowed)
+}
+
+func TestMemoryLimiter_Sweep(t *testing.T) {
+	clock := newFakeClock()
+	l := NewMemoryLimiter(TokenBucket).(*memoryLimiter)
+	l.now = clock.Now
+	rate := Rate{Limit: 1, Window: time.Second}
+
+	allowN(t, l, "a", rate, 1)
+	allowN(t, l, "b", rate, 1)
+	assert.Len(t, l.buckets, 2)
+
+	clock.Advance(2 * time.Second)
+	allowN(t, l, "c", rate, 1)
+	assert.Len(t, l.buckets, 1)
+	assert.Contains(t, l.buckets, "c")
+}
+
+func TestMemoryLimiter_SweepKeepsLongerWindows(t *testing.T) {
+	clock := newFakeClock()
+	l := NewMemoryLimiter(SlidingWindow).(*memoryLimiter)
+	l.now = clock.Now
+	short := Rate{Limit: 10, Window: time.Second}
+	long := Rate{Limit: 1, Window: time.Hour}
+
+	results := allowN(t, l, "route", long, 1)
+	assert.True(t, results[0].Allowed)
+
+	// a request of the shorter rate sweeps while the route's log is live
+	clock.Advance(2 * time.Second)
+	allowN(t, l, "root", short, 1)
+	assert.Contains(t, l.logs, "route")
+
+	results = allowN(t, l, "route", long, 1)
+	assert.False(t, results[0].Allowed)
+}
@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	LimitHeader      = "RateLimit-Limit"
+	RemainingHeader  = "RateLimit-Remaining"
+	ResetHeader      = "RateLimit-Reset"
+	RetryAfterHeader = "Retry-After"
+)
+
+type Config struct {
+	Limiter Limiter
+	// Rate applies when attached as a RootMiddleware. The zero Rate leaves
+	// requests unlimited there, for limiting selected routes only.
+	Rate Rate
+	// Key defaults to KeyByIP.
+	Key KeyFunc
+	// FailClosed rejects requests when the limiter errors. By default they
+	// are let through so an unavailable backend does not take the api down.
+	FailClosed bool
+	Logger     logger.Logger
+}
+
+type Middleware interface {
+	network.RootMiddleware
+	network.Param1MiddlewareProvider[Rate]
+}
+
+type middleware struct {
+	config Config
+	logger logger.Logger
+}
+
+// NewMiddleware panics when the Limiter is missing or the Rate is set but
+// not positive, so misconfigurations fail at startup instead of per request.
+func NewMiddleware(config Config) Middleware {
+	if config.Limiter == nil {
+		panic("ratelimit: Config.Limiter is required")
+	}
+	if config.Rate != (Rate{}) {
+		if err := config.Rate.validate(); err != nil {
+			panic("ratelimit: " + err.Error())
+		}
+	}
+	if config.Key == nil {
+		config.Key = KeyByIP
+	}
+	return &middleware{
+		config: config,
+		logger: logger.Or(config.Logger),
+	}
+}
+
+func (m *middleware) Attach(engine *gin.Engine) {
+	engine.Use(m.Handler)
+}
+
+func (m *middleware) Handler(ctx *gin.Context) {
+	if m.config.Rate == (Rate{}) {
+		ctx.Next()
+		return
+	}
+	m.limit(ctx, "global:"+m.config.Key(ctx), m.config.Rate)
+}
+
+// Middleware limits a single route with its own rate, counted separately
+// from the global limit and from other routes. It panics when rate is not
+// positive.
+func (m *middleware) Middleware(rate Rate) gin.HandlerFunc {
+	if err := rate.validate(); err != nil {
+		panic("ratelimit: " + err.Error())
+	}
+	return func(ctx *gin.Context) {
+		m.limit(ctx, "route:"+ctx.Request.Method+":"+ctx.FullPath()+":"+m.config.Key(ctx), rate)
+	}
+}
+
+func (m *middleware) limit(ctx *gin.Context, key string, rate Rate) {
+	result, err := m.config.Limiter.Allow(ctx.Request.Context(), key, rate)
+	if err != nil {
+		if m.config.FailClosed {
+			network.SendInternalServerError(ctx, "rate limit unavailable", err)
+			return
+		}
+		m.logger.Warn("rate limit check failed", "key", key, "error", err)
+		ctx.Next()
+		return
+	}
+
+	header := ctx.Writer.Header()
+	header.Set(LimitHeader, strconv.Itoa(result.Limit))
+	header.Set(RemainingHeader, strconv.Itoa(result.Remaining))
+	header.Set(ResetHeader, seconds(result.Reset))
+
+	if !result.Allowed {
+		header.Set(RetryAfterHeader, seconds(result.RetryAfter))
+		network.SendTooManyRequestsError(ctx, "too many requests", nil)
+		return
+	}
+
+	ctx.Next()
+}
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
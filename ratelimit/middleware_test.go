@@ -0,0 +1,150 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/auth"
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEngine(m Middleware, routes func(r *gin.Engine)) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	m.Attach(r)
+	routes(r)
+	return r
+}
+
+func get(r *gin.Engine, path string, headers map[string]string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestMiddleware_Root(t *testing.T) {
+	m := NewMiddleware(Config{
+		Limiter: NewMemoryLimiter(TokenBucket),
+		Rate:    Rate{Limit: 2, Window: time.Minute},
+	})
+	r := newTestEngine(m, func(r *gin.Engine) {
+		r.GET("/", network.MockSuccessMsgHandler("ok"))
+	})
+
+	rr := get(r, "/", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get(LimitHeader))
+	assert.Equal(t, "1", rr.Header().Get(RemainingHeader))
+	assert.Equal(t, "30", rr.Header().Get(ResetHeader))
+
+	get(r, "/", nil)
+	rr = get(r, "/", nil)
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "0", rr.Header().Get(RemainingHeader))
+	assert.Equal(t, "30", rr.Header().Get(RetryAfterHeader))
+	assert.Contains(t, rr.Body.String(), `"message":"too many requests"`)
+	assert.Contains(t, rr.Body.String(), `"code":"10001"`)
+}
+
+func TestMiddleware_Route(t *testing.T) {
+	m := NewMiddleware(Config{
+		Limiter: NewMemoryLimiter(SlidingWindow),
+		Rate:    Rate{Limit: 100, Window: time.Minute},
+		Key:     KeyByApiKey,
+	})
+	r := newTestEngine(m, func(r *gin.Engine) {
+		r.GET("/login", m.Middleware(Rate{Limit: 1, Window: time.Minute}), network.MockSuccessMsgHandler("ok"))
+		r.GET("/blogs", network.MockSuccessMsgHandler("ok"))
+	})
+
+	client1 := map[string]string{network.ApiKeyHeader: "key-1"}
+	client2 := map[string]string{network.ApiKeyHeader: "key-2"}
+
+	assert.Equal(t, http.StatusOK, get(r, "/login", client1).Code)
+	rr := get(r, "/login", client1)
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get(LimitHeader))
+
+	assert.Equal(t, http.StatusOK, get(r, "/login", client2).Code)
+
+	rr = get(r, "/blogs", client1)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "100", rr.Header().Get(LimitHeader))
+}
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(ctx context.Context, key string, rate Rate) (*Result, error) {
+	return nil, errors.New("backend down")
+}
+
+func TestMiddleware_LimiterError(t *testing.T) {
+	routes := func(r *gin.Engine) {
+		r.GET("/", network.MockSuccessMsgHandler("ok"))
+	}
+
+	rate := Rate{Limit: 1, Window: time.Minute}
+	open := newTestEngine(NewMiddleware(Config{Limiter: failingLimiter{}, Rate: rate, Logger: logger.Nop()}), routes)
+	assert.Equal(t, http.StatusOK, get(open, "/", nil).Code)
+
+	closed := newTestEngine(NewMiddleware(Config{Limiter: failingLimiter{}, Rate: rate, FailClosed: true}), routes)
+	assert.Equal(t, http.StatusInternalServerError, get(closed, "/", nil).Code)
+}
+
+func TestKeyFuncs(t *testing.T) {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx.Request.RemoteAddr = "10.0.0.1:1234"
+
+	assert.Equal(t, "ip:10.0.0.1", KeyByIP(ctx))
+	assert.Equal(t, "ip:10.0.0.1", KeyByApiKey(ctx))
+	assert.Equal(t, "ip:10.0.0.1", KeyByPrincipal(ctx))
+
+	ctx.Request.Header.Set(network.ApiKeyHeader, "secret")
+	assert.Equal(t, "apikey:"+auth.HashApiKey("secret"), KeyByApiKey(ctx))
+
+	auth.SetPrincipal(ctx, &auth.Principal{Id: "user-1", Type: auth.PrincipalJWT})
+	assert.Equal(t, "principal:jwt:user-1", KeyByPrincipal(ctx))
+}
+
+func TestMiddleware_InvalidRate(t *testing.T) {
+	limiter := NewMemoryLimiter(TokenBucket)
+
+	assert.Panics(t, func() {
+		NewMiddleware(Config{Limiter: limiter, Rate: Rate{Limit: 0, Window: time.Minute}})
+	})
+	assert.Panics(t, func() {
+		NewMiddleware(Config{Limiter: limiter, Rate: Rate{Limit: 1, Window: -time.Second}})
+	})
+	assert.Panics(t, func() {
+		NewMiddleware(Config{Rate: Rate{Limit: 1, Window: time.Minute}})
+	})
+
+	m := NewMiddleware(Config{Limiter: limiter})
+	assert.Panics(t, func() { m.Middleware(Rate{}) })
+	assert.Panics(t, func() { m.Middleware(Rate{Limit: 5}) })
+}
+
+func TestMiddleware_ZeroRateIsUnlimited(t *testing.T) {
+	m := NewMiddleware(Config{Limiter: NewMemoryLimiter(TokenBucket)})
+	r := newTestEngine(m, func(r *gin.Engine) {
+		r.GET("/", network.MockSuccessMsgHandler("ok"))
+	})
+
+	for range 3 {
+		rr := get(r, "/", nil)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get(LimitHeader))
+	}
+}
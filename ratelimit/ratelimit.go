@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type Algorithm int
+
+const (
+	// TokenBucket allows bursts up to Limit and refills Limit tokens per Window.
+	TokenBucket Algorithm = iota
+	// SlidingWindow allows at most Limit requests in any Window long interval.
+	SlidingWindow
+)
+
+// Rate allows Limit requests per Window, both of which must be positive.
+type Rate struct {
+	Limit  int
+	Window time.Duration
+}
+
+func (r Rate) validate() error {
+	if r.Limit <= 0 || r.Window <= 0 {
+		return fmt.Errorf("invalid rate limit %d per %s, both must be positive", r.Limit, r.Window)
+	}
+	return nil
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the limit is fully available again.
+	Reset time.Duration
+	// RetryAfter is the time until the next request may be allowed, zero when allowed.
+	RetryAfter time.Duration
+}
+
+type Limiter interface {
+	Allow(ctx context.Context, key string, rate Rate) (*Result, error)
+}
+
+func tokenBucketResult(rate Rate, tokens float64, allowed bool) *Result {
+	perToken := rate.Window / time.Duration(rate.Limit)
+	result := &Result{
+		Allowed:   allowed,
+		Limit:     rate.Limit,
+		Remaining: int(tokens),
+		Reset:     time.Duration((float64(rate.Limit) - tokens) * float64(perToken)),
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(perToken))
+	}
+	return result
+}
+
+func slidingWindowResult(rate Rate, count int, oldest, newest, now time.Time, allowed bool) *Result {
+	result := &Result{
+		Allowed:   allowed,
+		Limit:     rate.Limit,
+		Remaining: max(rate.Limit-count, 0),
+		Reset:     max(newest.Add(rate.Window).Sub(now), 0),
+	}
+	if !allowed {
+		result.RetryAfter = max(oldest.Add(rate.Window).Sub(now), 0)
+	}
+	return result
+}
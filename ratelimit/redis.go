@@ -0,0 +1,121 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/redis"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+var tokenBucketScript = goredis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or capacity
+local ts = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+var slidingWindowScript = goredis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+local newest = redis.call('ZRANGE', KEYS[1], -1, -1, 'WITHSCORES')
+return {allowed, count, oldest[2] or tostring(now), newest[2] or tostring(now)}
+`)
+
+type redisLimiter struct {
+	store     redis.Store
+	algorithm Algorithm
+	prefix    string
+	now       func() time.Time
+}
+
+// NewRedisLimiter shares limits across instances. Each check is a single
+// Lua script so concurrent requests cannot overshoot the limit.
+func NewRedisLimiter(store redis.Store, algorithm Algorithm, prefix string) Limiter {
+	return &redisLimiter{
+		store:     store,
+		algorithm: algorithm,
+		prefix:    prefix,
+		now:       time.Now,
+	}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, rate Rate) (*Result, error) {
+	// the scripts count in milliseconds
+	if rate.Window < time.Millisecond {
+		return nil, fmt.Errorf("rate window %s is shorter than the 1ms redis limiters support", rate.Window)
+	}
+	if l.algorithm == SlidingWindow {
+		return l.slidingWindow(ctx, key, rate)
+	}
+	return l.tokenBucket(ctx, key, rate)
+}
+
+func (l *redisLimiter) tokenBucket(ctx context.Context, key string, rate Rate) (*Result, error) {
+	perMs := float64(rate.Limit) / float64(rate.Window.Milliseconds())
+	values, err := tokenBucketScript.Run(ctx, l.store.GetInstance(), []string{l.prefix + key},
+		rate.Limit, perMs, l.now().UnixMilli()).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := strconv.ParseFloat(fmt.Sprint(values[1]), 64)
+	if err != nil {
+		return nil, err
+	}
+	return tokenBucketResult(rate, tokens, values[0].(int64) == 1), nil
+}
+
+func (l *redisLimiter) slidingWindow(ctx context.Context, key string, rate Rate) (*Result, error) {
+	now := l.now()
+	member := fmt.Sprintf("%d-%d", now.UnixNano(), rand.Uint64())
+	values, err := slidingWindowScript.Run(ctx, l.store.GetInstance(), []string{l.prefix + key},
+		rate.Limit, rate.Window.Milliseconds(), now.UnixMilli(), member).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	oldest, err := parseMillis(values[2])
+	if err != nil {
+		return nil, err
+	}
+	newest, err := parseMillis(values[3])
+	if err != nil {
+		return nil, err
+	}
+	count := int(values[1].(int64))
+	return slidingWindowResult(rate, count, oldest, newest, now, values[0].(int64) == 1), nil
+}
+
+func parseMillis(value any) (time.Time, error) {
+	ms, err := strconv.ParseFloat(fmt.Sprint(value), 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(int64(ms)), nil
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/redis"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRedisLimiter(t *testing.T, algorithm Algorithm, clock *fakeClock) (*redisLimiter, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	store := redis.NewStore(context.Background(), &redis.Config{
+		Host:   mr.Host(),
+		Port:   uint16(mr.Server().Addr().Port),
+		Logger: logger.Nop(),
+	})
+	t.Cleanup(store.Disconnect)
+
+	l := NewRedisLimiter(store, algorithm, "ratelimit:").(*redisLimiter)
+	l.now = clock.Now
+	return l, mr
+}
+
+func TestRedisLimiter_TokenBucket(t *testing.T) {
+	clock := newFakeClock()
+	l, mr := newTestRedisLimiter(t, TokenBucket, clock)
+	rate := Rate{Limit: 3, Window: 3 * time.Second}
+
+	results := allowN(t, l, "a", rate, 4)
+	assert.True(t, results[0].Allowed)
+	assert.Equal(t, 2, results[0].Remaining)
+	assert.True(t, results[2].Allowed)
+	assert.False(t, results[3].Allowed)
+	assert.Equal(t, time.Second, results[3].RetryAfter)
+	assert.True(t, mr.Exists("ratelimit:a"))
+
+	clock.Advance(time.Second)
+	results = allowN(t, l, "a", rate, 2)
+	assert.True(t, results[0].Allowed)
+	assert.False(t, results[1].Allowed)
+}
+
+func TestRedisLimiter_SlidingWindow(t *testing.T) {
+	clock := newFakeClock()
+	l, _ := newTestRedisLimiter(t, SlidingWindow, clock)
+	rate := Rate{Limit: 2, Window: time.Minute}
+
+	results := allowN(t, l, "a", rate, 1)
+	assert.True(t, results[0].Allowed)
+	assert.Equal(t, 1, results[0].Remaining)
+
+	clock.Advance(30 * time.Second)
+	results = allowN(t, l, "a", rate, 2)
+	assert.True(t, results[0].Allowed)
+	assert.False(t, results[1].Allowed)
+	assert.Equal(t, 30*time.Second, results[1].RetryAfter)
+	assert.Equal(t, time.Minute, results[1].Reset)
+
+	clock.Advance(31 * time.Second)
+	results = allowN(t, l, "a", rate, 2)
+	assert.True(t, results[0].Allowed)
+	assert.False(t, results[1].Allowed)
+}
+
+func TestRedisLimiter_Error(t *testing.T) {
+	l, mr := newTestRedisLimiter(t, TokenBucket, newFakeClock())
+	mr.Close()
+
+	_, err := l.Allow(context.Background(), "a", Rate{Limit: 1, Window: time.Second})
+	assert.Error(t, err)
+}
+
+func TestRedisLimiter_WindowTooShort(t *testing.T) {
+	for _, algorithm := range []Algorithm{TokenBucket, SlidingWindow} {
+		l, mr := newTestRedisLimiter(t, algorithm, newFakeClock())
+
+		_, err := l.Allow(context.Background(), "a", Rate{Limit: 1, Window: time.Microsecond})
+		assert.Error(t, err)
+		assert.False(t, mr.Exists("ratelimit:a"))
+	}
+}
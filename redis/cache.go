@@ -3,10 +3,17 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
+
+	"github.com/afteracademy/goserve/v2/tracing"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Cache[T any] interface {
+	WithContext(ctx context.Context) Cache[T]
 	SetJSON(key string, value *T, expiration time.Duration) error
 	GetJSON(key string) (*T, error)
 	SetJSONList(key string, values []*T, expiration time.Duration) error
@@ -25,17 +32,61 @@ func NewCache[T any](store Store) Cache[T] {
 	}
 }
 
-func (c *cache[T]) SetJSON(key string, value *T, expiration time.Duration) error {
+// WithContext returns a copy of the cache bound to ctx, so its calls are
+// traced as children of the span in ctx.
+func (c *cache[T]) WithContext(ctx context.Context) Cache[T] {
+	return &cache[T]{
+		context: ctx,
+		store:   c.store,
+	}
+}
+
+func (c *cache[T]) startSpan(operation string, key string) (context.Context, trace.Span) {
+	return c.store.GetInstance().tracer.Start(c.context, "redis "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "redis"),
+			attribute.String("db.operation.name", operation),
+			attribute.String("redis.key", key),
+		),
+	)
+}
+
+func (c *cache[T]) observe(err error) {
+	if err == nil {
+		c.store.GetInstance().metrics.ObserveCache(true)
+	} else if errors.Is(err, redis.Nil) {
+		c.store.GetInstance().metrics.ObserveCache(false)
+	}
+}
+
+// endSpan does not flag a cache miss as a span error, it is an expected outcome
+func endSpan(span trace.Span, err error) {
+	if errors.Is(err, redis.Nil) {
+		span.SetAttributes(attribute.Bool("cache.hit", false))
+		err = nil
+	}
+	tracing.End(span, err)
+}
+
+func (c *cache[T]) SetJSON(key string, value *T, expiration time.Duration) (err error) {
+	ctx, span := c.startSpan("SetJSON", key)
+	defer func() { endSpan(span, err) }()
+
 	data, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
 
-	return c.store.GetInstance().Set(c.context, key, data, expiration).Err()
+	return c.store.GetInstance().Set(ctx, key, data, expiration).Err()
 }
 
-func (c *cache[T]) GetJSON(key string) (*T, error) {
-	data, err := c.store.GetInstance().Get(c.context, key).Bytes()
+func (c *cache[T]) GetJSON(key string) (_ *T, err error) {
+	ctx, span := c.startSpan("GetJSON", key)
+	defer func() { endSpan(span, err) }()
+	defer func() { c.observe(err) }()
+
+	data, err := c.store.GetInstance().Get(ctx, key).Bytes()
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +100,10 @@ func (c *cache[T]) GetJSON(key string) (*T, error) {
 	return &dest, nil
 }
 
-func (c *cache[T]) SetJSONList(key string, values []*T, expiration time.Duration) error {
+func (c *cache[T]) SetJSONList(key string, values []*T, expiration time.Duration) (err error) {
+	ctx, span := c.startSpan("SetJSONList", key)
+	defer func() { endSpan(span, err) }()
+
 	var list []json.RawMessage
 	for _, value := range values {
 		data, err := json.Marshal(value)
@@ -64,11 +118,15 @@ func (c *cache[T]) SetJSONList(key string, values []*T, expiration time.Duration
 		return err
 	}
 
-	return c.store.GetInstance().Set(c.context, key, str, expiration).Err()
+	return c.store.GetInstance().Set(ctx, key, str, expiration).Err()
 }
 
-func (c *cache[T]) GetJSONList(key string) ([]*T, error) {
-	str, err := c.store.GetInstance().Get(c.context, key).Result()
+func (c *cache[T]) GetJSONList(key string) (_ []*T, err error) {
+	ctx, span := c.startSpan("GetJSONList", key)
+	defer func() { endSpan(span, err) }()
+	defer func() { c.observe(err) }()
+
+	str, err := c.store.GetInstance().Get(ctx, key).Result()
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,131 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/metrics"
+	"github.com/afteracademy/goserve/v2/tracing"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type testItem struct {
+	Name string `json:"name"`
+}
+
+func newTestStore(t *testing.T, tp trace.TracerProvider) Store {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	store := NewStore(context.Background(), &Config{
+		Host:           mr.Host(),
+		Port:           uint16(mr.Server().Addr().Port),
+		Logger:         logger.Nop(),
+		TracerProvider: tp,
+	})
+	t.Cleanup(func() { store.GetInstance().Close() })
+	return store
+}
+
+func TestCache_JSON(t *testing.T) {
+	cache := NewCache[testItem](newTestStore(t, nil))
+
+	err := cache.SetJSON("item", &testItem{Name: "goserve"}, time.Minute)
+	assert.NoError(t, err)
+
+	item, err := cache.GetJSON("item")
+	assert.NoError(t, err)
+	assert.Equal(t, "goserve", item.Name)
+
+	_, err = cache.GetJSON("missing")
+	assert.ErrorIs(t, err, redis.Nil)
+}
+
+func TestCache_JSONList(t *testing.T) {
+	cache := NewCache[testItem](newTestStore(t, nil))
+
+	err := cache.SetJSONList("items", []*testItem{{Name: "a"}, {Name: "b"}}, time.Minute)
+	assert.NoError(t, err)
+
+	items, err := cache.GetJSONList("items")
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, "b", items[1].Name)
+}
+
+func TestCache_TracingSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	store := newTestStore(t, tp)
+
+	parentCtx, parent := tracing.Tracer(tp).Start(context.Background(), "handler")
+	defer parent.End()
+
+	cache := NewCache[testItem](store).WithContext(parentCtx)
+
+	assert.NoError(t, cache.SetJSON("item", &testItem{Name: "goserve"}, time.Minute))
+	_, err := cache.GetJSON("missing")
+	assert.Error(t, err)
+	store.GetInstance().Set(context.Background(), "broken", "{", time.Minute)
+	_, err = cache.GetJSON("broken")
+	assert.Error(t, err)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 3)
+
+	assert.Equal(t, "redis SetJSON", spans[0].Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Contains(t, spans[0].Attributes, attribute.String("redis.key", "item"))
+
+	assert.Equal(t, codes.Unset, spans[1].Status.Code)
+	assert.Contains(t, spans[1].Attributes, attribute.Bool("cache.hit", false))
+
+	assert.Equal(t, codes.Error, spans[2].Status.Code)
+}
+
+func TestCache_Metrics(t *testing.T) {
+	m := metrics.New(metrics.Config{Namespace: "test", Registry: prometheus.NewRegistry()})
+	mr := miniredis.RunT(t)
+	store := NewStore(context.Background(), &Config{
+		Host:    mr.Host(),
+		Port:    uint16(mr.Server().Addr().Port),
+		Logger:  logger.Nop(),
+		Metrics: m,
+	})
+	defer store.GetInstance().Close()
+
+	cache := NewCache[testItem](store)
+	assert.NoError(t, cache.SetJSON("item", &testItem{Name: "goserve"}, time.Minute))
+	cache.GetJSON("item")
+	cache.GetJSON("missing")
+	cache.GetJSON("missing")
+
+	count, err := testutil.GatherAndCount(m.Registry(), "test_cache_requests_total")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	hits, _ := m.Registry().Gather()
+	for _, mf := range hits {
+		if mf.GetName() != "test_cache_requests_total" {
+			continue
+		}
+		for _, metric := range mf.GetMetric() {
+			switch metric.GetLabel()[0].GetValue() {
+			case "hit":
+				assert.Equal(t, float64(1), metric.GetCounter().GetValue())
+			case "miss":
+				assert.Equal(t, float64(2), metric.GetCounter().GetValue())
+			}
+		}
+	}
+}
@@ -2,28 +2,47 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/metrics"
+	"github.com/afteracademy/goserve/v2/tracing"
+	"github.com/afteracademy/goserve/v2/utility"
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Config struct {
-	Host string
-	Port uint16
-	Pwd  string
-	DB   int
+	Host   string
+	Port   uint16
+	Pwd    string
+	DB     int
+	Retry  utility.RetryConfig
+	Logger logger.Logger
+	// TracerProvider enables spans for Cache calls when set.
+	TracerProvider trace.TracerProvider
+	// Metrics records cache hits and misses when set.
+	Metrics *metrics.Metrics
 }
 
+var ErrConnect = errors.New("could not connect to redis")
+
 type Store interface {
 	GetInstance() *store
 	Connect()
+	ConnectContext(ctx context.Context) error
 	Disconnect()
+	DisconnectContext(ctx context.Context) error
 }
 
 type store struct {
 	*redis.Client
 	context context.Context
+	config  *Config
+	logger  logger.Logger
+	tracer  trace.Tracer
+	metrics *metrics.Metrics
 }
 
 func NewStore(context context.Context, config *Config) Store {
@@ -35,6 +54,10 @@ func NewStore(context context.Context, config *Config) Store {
 	return &store{
 		context: context,
 		Client:  client,
+		config:  config,
+		logger:  logger.Or(config.Logger),
+		tracer:  tracing.Tracer(config.TracerProvider),
+		metrics: config.Metrics,
 	}
 }
 
@@ -43,19 +66,43 @@ func (r *store) GetInstance() *store {
 }
 
 func (r *store) Connect() {
-	fmt.Println("connecting to redis")
-	pong, err := r.Ping(r.context).Result()
+	if err := r.ConnectContext(r.context); err != nil {
+		r.logger.Error("connection to redis failed", "error", err)
+		panic(err)
+	}
+}
+
+func (r *store) ConnectContext(ctx context.Context) error {
+	r.logger.Info("connecting to redis", "addr", r.Options().Addr, "db", r.config.DB)
+	var pong string
+	err := utility.Retry(ctx, r.config.Retry, func(ctx context.Context) error {
+		res, err := r.Ping(ctx).Result()
+		if err != nil {
+			r.logger.Warn("redis connection attempt failed", "error", err)
+			return fmt.Errorf("%w: %w", ErrConnect, err)
+		}
+		pong = res
+		return nil
+	})
 	if err != nil {
-		panic(fmt.Errorf("could not connect to redis: %v", err))
+		return err
 	}
-	fmt.Println("connected to Redis:", pong)
+	r.logger.Info("connected to redis", "pong", pong)
+	return nil
 }
 
 func (r *store) Disconnect() {
-	fmt.Println("disconnecting redis...")
-	err := r.Close()
-	if err != nil {
-		log.Panic(err)
+	if err := r.DisconnectContext(r.context); err != nil {
+		r.logger.Error("disconnecting redis failed", "error", err)
+		panic(err)
+	}
+}
+
+func (r *store) DisconnectContext(ctx context.Context) error {
+	r.logger.Info("disconnecting redis")
+	if err := r.Close(); err != nil {
+		return err
 	}
-	fmt.Println("disconnected redis")
+	r.logger.Info("disconnected redis")
+	return nil
 }
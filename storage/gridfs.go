@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/afteracademy/goserve/v2/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrNotConnected = errors.New("database not connected")
+
+type gridFSStorage struct {
+	db     mongo.Database
+	bucket string
+}
+
+// NewGridFSStorage keeps files in the GridFS bucket of db, "fs" when
+// bucket is empty. The database may connect after the storage is created.
+func NewGridFSStorage(db mongo.Database, bucket string) Storage {
+	if bucket == "" {
+		bucket = options.DefaultName
+	}
+	return &gridFSStorage{db: db, bucket: bucket}
+}
+
+// open creates a bucket per call as deadlines are set on the bucket
+func (s *gridFSStorage) open(ctx context.Context) (*gridfs.Bucket, error) {
+	instance := s.db.GetInstance()
+	if instance == nil || instance.Database == nil {
+		return nil, ErrNotConnected
+	}
+	bucket, err := gridfs.NewBucket(instance.Database, options.GridFSBucket().SetName(s.bucket))
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		bucket.SetReadDeadline(deadline)
+		bucket.SetWriteDeadline(deadline)
+	}
+	return bucket, nil
+}
+
+func (s *gridFSStorage) Save(ctx context.Context, name string, contentType string, r io.Reader) (*File, error) {
+	bucket, err := s.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := &countingReader{Reader: r}
+	opts := options.GridFSUpload().SetMetadata(bson.M{"contentType": contentType})
+	id, err := bucket.UploadFromStream(name, contextReader{ctx, counter}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &File{
+		Id:          id.Hex(),
+		Name:        name,
+		Size:        counter.n,
+		ContentType: contentType,
+		CreatedAt:   id.Timestamp().UTC(),
+	}, nil
+}
+
+func (s *gridFSStorage) Open(ctx context.Context, id string) (io.ReadCloser, *File, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil, ErrNotFound
+	}
+	bucket, err := s.open(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stream, err := bucket.OpenDownloadStream(oid)
+	if err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	info := stream.GetFile()
+	file := &File{
+		Id:        id,
+		Name:      info.Name,
+		Size:      info.Length,
+		CreatedAt: info.UploadDate,
+	}
+	if ct, ok := info.Metadata.Lookup("contentType").StringValueOK(); ok {
+		file.ContentType = ct
+	}
+	return stream, file, nil
+}
+
+func (s *gridFSStorage) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrNotFound
+	}
+	bucket, err := s.open(ctx)
+	if err != nil {
+		return err
+	}
+	if err := bucket.DeleteContext(ctx, oid); err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/afteracademy/goserve/v2/mongo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGridFSStorage_NotConnected(t *testing.T) {
+	s := NewGridFSStorage(mongo.NewDatabase(context.Background(), mongo.DbConfig{}), "")
+
+	_, err := s.Save(context.Background(), "a.txt", "text/plain", strings.NewReader("a"))
+	assert.ErrorIs(t, err, ErrNotConnected)
+
+	id := "65f1c2a4e1b2c3d4e5f6a7b8"
+	_, _, err = s.Open(context.Background(), id)
+	assert.ErrorIs(t, err, ErrNotConnected)
+	assert.ErrorIs(t, s.Delete(context.Background(), id), ErrNotConnected)
+}
+
+func TestGridFSStorage_InvalidId(t *testing.T) {
+	s := NewGridFSStorage(mongo.NewDatabase(context.Background(), mongo.DbConfig{}), "uploads")
+
+	_, _, err := s.Open(context.Background(), "nope")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, s.Delete(context.Background(), "nope"), ErrNotFound)
+}
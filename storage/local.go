@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var localIdPattern = regexp.MustCompile(`^[0-9a-f]{32}(\.[a-zA-Z0-9]{1,10})?$`)
+
+type localStorage struct {
+	dir string
+}
+
+// NewLocalStorage keeps files in dir under random ids that retain the
+// original extension, with their metadata in a .meta.json file beside.
+func NewLocalStorage(dir string) (Storage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &localStorage{dir: dir}, nil
+}
+
+func (s *localStorage) Save(ctx context.Context, name string, contentType string, r io.Reader) (_ *File, err error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	id := hex.EncodeToString(b)
+	if ext := filepath.Ext(name); localIdPattern.MatchString(id + ext) {
+		id += strings.ToLower(ext)
+	}
+
+	// written to a temp file first so readers never see a partial file
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	counter := &countingReader{Reader: r}
+	_, err = io.Copy(tmp, contextReader{ctx, counter})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file := &File{
+		Id:          id,
+		Name:        name,
+		Size:        counter.n,
+		ContentType: contentType,
+		CreatedAt:   time.Now().UTC(),
+	}
+	meta, err := json.Marshal(file)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(s.metaPath(id), meta, 0o644); err != nil {
+		return nil, err
+	}
+	if err = os.Rename(tmp.Name(), filepath.Join(s.dir, id)); err != nil {
+		os.Remove(s.metaPath(id))
+		return nil, err
+	}
+	return file, nil
+}
+
+func (s *localStorage) Open(ctx context.Context, id string) (io.ReadCloser, *File, error) {
+	if !localIdPattern.MatchString(id) {
+		return nil, nil, ErrNotFound
+	}
+	meta, err := os.ReadFile(s.metaPath(id))
+	if err != nil {
+		return nil, nil, notFound(err)
+	}
+	var file File
+	if err := json.Unmarshal(meta, &file); err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(filepath.Join(s.dir, id))
+	if err != nil {
+		return nil, nil, notFound(err)
+	}
+	return f, &file, nil
+}
+
+func (s *localStorage) Delete(ctx context.Context, id string) error {
+	if !localIdPattern.MatchString(id) {
+		return ErrNotFound
+	}
+	if err := os.Remove(filepath.Join(s.dir, id)); err != nil {
+		return notFound(err)
+	}
+	if err := os.Remove(s.metaPath(id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *localStorage) metaPath(id string) string {
+	return filepath.Join(s.dir, id+".meta.json")
+}
+
+func notFound(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// contextReader stops a copy once ctx is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
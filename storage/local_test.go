@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(filepath.Join(dir, "uploads"))
+	assert.NoError(t, err)
+
+	file, err := s.Save(context.Background(), "report.PDF", "application/pdf", strings.NewReader("content"))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(file.Id, ".pdf"))
+	assert.Equal(t, "report.PDF", file.Name)
+	assert.Equal(t, int64(7), file.Size)
+	assert.False(t, file.CreatedAt.IsZero())
+
+	r, meta, err := s.Open(context.Background(), file.Id)
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "content", string(data))
+	assert.Equal(t, file.ContentType, meta.ContentType)
+	assert.Equal(t, file.Size, meta.Size)
+
+	assert.NoError(t, s.Delete(context.Background(), file.Id))
+	_, _, err = s.Open(context.Background(), file.Id)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, s.Delete(context.Background(), file.Id), ErrNotFound)
+
+	entries, _ := os.ReadDir(filepath.Join(dir, "uploads"))
+	assert.Empty(t, entries)
+}
+
+func TestLocalStorage_RejectsPaths(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	_, _, err = s.Open(context.Background(), "../etc/passwd")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, s.Delete(context.Background(), "../secret"), ErrNotFound)
+}
+
+func TestLocalStorage_CanceledContext(t *testing.T) {
+	dir := t.TempDir()
+	s, _ := NewLocalStorage(dir)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.Save(ctx, "a.txt", "text/plain", strings.NewReader("content"))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var ErrNotFound = errors.New("file not found")
+
+// File is the metadata of a stored file.
+type File struct {
+	Id          string    `json:"id"`
+	Name        string    `json:"name"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"contentType"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type Storage interface {
+	// Save streams r into a new file named after the original name.
+	Save(ctx context.Context, name string, contentType string, r io.Reader) (*File, error)
+	Open(ctx context.Context, id string) (io.ReadCloser, *File, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// SaveUpload stores a multipart upload with its sniffed content type, as
+// the one sent by the client can not be trusted.
+func SaveUpload(ctx context.Context, s Storage, upload *multipart.FileHeader) (*File, error) {
+	f, err := upload.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	head = head[:n]
+	contentType, _, _ := strings.Cut(http.DetectContentType(head), ";")
+
+	return s.Save(ctx, filepath.Base(upload.Filename), contentType, io.MultiReader(bytes.NewReader(head), f))
+}
+
+// SaveUploads stores all uploads or none, deleting the saved ones when
+// one fails.
+func SaveUploads(ctx context.Context, s Storage, uploads []*multipart.FileHeader) ([]*File, error) {
+	files := make([]*File, 0, len(uploads))
+	for _, upload := range uploads {
+		file, err := SaveUpload(ctx, s, upload)
+		if err != nil {
+			for _, saved := range files {
+				s.Delete(ctx, saved.Id)
+			}
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
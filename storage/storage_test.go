@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func uploads(t *testing.T, files map[string]string) []*multipart.FileHeader {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for name, content := range files {
+		part, err := w.CreateFormFile("files", name)
+		assert.NoError(t, err)
+		part.Write([]byte(content))
+	}
+	assert.NoError(t, w.Close())
+
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(1 << 20)
+	assert.NoError(t, err)
+	return form.File["files"]
+}
+
+func TestSaveUpload(t *testing.T) {
+	s, _ := NewLocalStorage(t.TempDir())
+	upload := uploads(t, map[string]string{"../../page.html": "<html><body>hi</body></html>"})[0]
+
+	file, err := SaveUpload(context.Background(), s, upload)
+	assert.NoError(t, err)
+	assert.Equal(t, "page.html", file.Name)
+	assert.Equal(t, "text/html", file.ContentType)
+	assert.Equal(t, upload.Size, file.Size)
+
+	r, _, err := s.Open(context.Background(), file.Id)
+	assert.NoError(t, err)
+	defer r.Close()
+	data, _ := io.ReadAll(r)
+	assert.Equal(t, "<html><body>hi</body></html>", string(data))
+}
+
+type failingStorage struct {
+	Storage
+	saves   int
+	deleted []string
+}
+
+func (s *failingStorage) Save(ctx context.Context, name string, contentType string, r io.Reader) (*File, error) {
+	s.saves++
+	if s.saves > 1 {
+		return nil, errors.New("disk full")
+	}
+	return &File{Id: strings.ToUpper(name)}, nil
+}
+
+func (s *failingStorage) Delete(ctx context.Context, id string) error {
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+func TestSaveUploads_RollsBack(t *testing.T) {
+	s := &failingStorage{}
+	_, err := SaveUploads(context.Background(), s, uploads(t, map[string]string{"a.txt": "a", "b.txt": "b"}))
+
+	assert.EqualError(t, err, "disk full")
+	assert.Len(t, s.deleted, 1)
+}
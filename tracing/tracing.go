@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"net/textproto"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const InstrumentationName = "github.com/afteracademy/goserve/v2"
+
+// Propagator injects and extracts W3C trace-context and baggage headers.
+var Propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// Tracer returns a tracer from tp. Tracing is opt-in, so a nil provider
+// yields a no-op tracer.
+func Tracer(tp trace.TracerProvider) trace.Tracer {
+	if tp == nil {
+		tp = noop.NewTracerProvider()
+	}
+	return tp.Tracer(InstrumentationName)
+}
+
+// End records err on the span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Inject writes the trace context of ctx into HTTP or NATS headers.
+func Inject(ctx context.Context, headers map[string][]string) {
+	Propagator.Inject(ctx, headerCarrier(headers))
+}
+
+// Extract reads a trace context from HTTP or NATS headers.
+func Extract(ctx context.Context, headers map[string][]string) context.Context {
+	return Propagator.Extract(ctx, headerCarrier(headers))
+}
+
+// headerCarrier matches keys case-insensitively since NATS, unlike
+// net/http, does not canonicalize header names.
+type headerCarrier map[string][]string
+
+func (c headerCarrier) Get(key string) string {
+	for k, v := range c {
+		if strings.EqualFold(k, key) && len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+func (c headerCarrier) Set(key, value string) {
+	for k := range c {
+		if strings.EqualFold(k, key) {
+			delete(c, k)
+		}
+	}
+	c[textproto.CanonicalMIMEHeaderKey(key)] = []string{value}
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,68 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracer_NilProviderIsNoop(t *testing.T) {
+	_, span := Tracer(nil).Start(context.Background(), "noop")
+	assert.False(t, span.SpanContext().IsValid())
+	span.End()
+}
+
+func TestEnd_RecordsError(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	_, span := Tracer(tp).Start(context.Background(), "failing")
+	End(span, errors.New("boom"))
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "boom", spans[0].Status.Description)
+}
+
+func TestInjectExtract(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	ctx, span := Tracer(tp).Start(context.Background(), "parent")
+	defer span.End()
+
+	headers := http.Header{}
+	Inject(ctx, headers)
+	assert.NotEmpty(t, headers.Get("Traceparent"))
+
+	extracted := Extract(context.Background(), headers)
+	assert.Equal(t, span.SpanContext().TraceID(), trace.SpanContextFromContext(extracted).TraceID())
+}
+
+func TestExtract_CaseInsensitive(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	ctx, span := Tracer(tp).Start(context.Background(), "parent")
+	defer span.End()
+
+	headers := map[string][]string{}
+	Inject(ctx, headers)
+
+	lowered := map[string][]string{}
+	for k, v := range headers {
+		lowered[strings.ToLower(k)] = v
+	}
+
+	extracted := Extract(context.Background(), lowered)
+	assert.Equal(t, span.SpanContext().SpanID(), trace.SpanContextFromContext(extracted).SpanID())
+}
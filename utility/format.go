@@ -41,6 +41,10 @@ var Messages = map[string]string{
 	"ipv4":     "%s must be a valid IPv4 address",
 	"ipv6":     "%s must be a valid IPv6 address",
 	"hostname": "%s must be a valid hostname",
+	"e164":     "%s must be a valid E.164 phone number",
+	"timezone": "%s must be a valid time zone",
+	"mongoid":  "%s must be a valid id",
+	"slug":     "%s must contain only lowercase letters, numbers and dashes",
 
 	// Numbers
 	"gt":  "%s must be greater than %s",
@@ -66,7 +70,16 @@ var Messages = map[string]string{
 	"required_without_all": "%s is required",
 
 	// Security / enums
-	"oneof": "%s must be one of %s",
+	"oneof":          "%s must be one of %s",
+	"strongpassword": "%s must contain upper and lower case letters, a number and a symbol",
+
+	// Files
+	"filesize": "%s must not be larger than %s",
+	"maxfiles": "%s must have at most %s files",
+	"mimetype": "%s must be of type %s",
+
+	// Binding
+	"type": "%s must be a valid %s",
 }
 
 func FormatValidationErrors(err error) []string {
@@ -76,27 +89,30 @@ func FormatValidationErrors(err error) []string {
 	}
 
 	msgs := make([]string, 0, len(errs))
-
 	for _, e := range errs {
-		format, ok := Messages[e.Tag()]
-		if !ok {
-			format = "%s is invalid"
-		}
-
-		field := strings.ToLower(e.Field())
-		param := e.Param()
-
-		verbCount := strings.Count(format, "%s")
-
-		switch verbCount {
-		case 1:
-			msgs = append(msgs, fmt.Sprintf(format, field))
-		case 2:
-			msgs = append(msgs, fmt.Sprintf(format, field, param))
-		default:
-			msgs = append(msgs, fmt.Sprintf("%s is invalid", field))
-		}
+		msgs = append(msgs, FormatFieldError(e))
 	}
 
 	return msgs
 }
+
+func FormatFieldError(e validator.FieldError) string {
+	format, ok := Messages[e.Tag()]
+	if !ok {
+		format = "%s is invalid"
+	}
+
+	field := strings.ToLower(e.Field())
+	param := e.Param()
+
+	verbCount := strings.Count(format, "%s")
+
+	switch verbCount {
+	case 1:
+		return fmt.Sprintf(format, field)
+	case 2:
+		return fmt.Sprintf(format, field, param)
+	default:
+		return fmt.Sprintf("%s is invalid", field)
+	}
+}
@@ -0,0 +1,74 @@
+package utility
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultRetryDelay      = 500 * time.Millisecond
+	defaultRetryMaxDelay   = 30 * time.Second
+	defaultRetryMultiplier = 2
+)
+
+// RetryConfig describes an exponential backoff. The zero value makes a single attempt.
+type RetryConfig struct {
+	Attempts   int
+	Delay      time.Duration
+	MaxDelay   time.Duration
+	Multiplier float64
+}
+
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("failed after %d attempt(s): %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+func Retry(ctx context.Context, config RetryConfig, fn func(ctx context.Context) error) error {
+	attempts := max(config.Attempts, 1)
+	delay := config.Delay
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+	maxDelay := config.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+	multiplier := config.Multiplier
+	if multiplier < 1 {
+		multiplier = defaultRetryMultiplier
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+
+		if attempt == attempts {
+			break
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &RetryError{Attempts: attempt, Err: errors.Join(err, ctx.Err())}
+		case <-timer.C:
+		}
+
+		delay = min(time.Duration(float64(delay)*multiplier), maxDelay)
+	}
+
+	return &RetryError{Attempts: attempts, Err: err}
+}
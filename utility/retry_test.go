@@ -0,0 +1,77 @@
+package utility
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry_SucceedsFirstAttempt(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), RetryConfig{Attempts: 3}, func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetry_SucceedsAfterFailures(t *testing.T) {
+	calls := 0
+	config := RetryConfig{Attempts: 3, Delay: time.Millisecond}
+	err := Retry(context.Background(), config, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetry_ReturnsRetryError(t *testing.T) {
+	cause := errors.New("unreachable")
+	config := RetryConfig{Attempts: 2, Delay: time.Millisecond}
+	err := Retry(context.Background(), config, func(ctx context.Context) error {
+		return cause
+	})
+
+	var retryErr *RetryError
+	assert.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, 2, retryErr.Attempts)
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestRetry_ZeroValueMakesSingleAttempt(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), RetryConfig{}, func(ctx context.Context) error {
+		calls++
+		return errors.New("failed")
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetry_StopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	config := RetryConfig{Attempts: 5, Delay: time.Hour}
+	err := Retry(ctx, config, func(ctx context.Context) error {
+		calls++
+		cancel()
+		return errors.New("failed")
+	})
+
+	var retryErr *RetryError
+	assert.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, 1, retryErr.Attempts)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,42 @@
+package ws
+
+import (
+	"github.com/afteracademy/goserve/v2/micro"
+	"github.com/nats-io/nats.go"
+)
+
+// Broker relays hub broadcasts between instances. Subscribers receive
+// every published payload, their own included.
+type Broker interface {
+	Publish(data []byte) error
+	Subscribe(handler func(data []byte)) (unsubscribe func() error, err error)
+}
+
+type natsBroker struct {
+	client  micro.NatsClient
+	subject string
+}
+
+func NewNatsBroker(client micro.NatsClient, subject string) Broker {
+	return &natsBroker{client: client, subject: subject}
+}
+
+func (b *natsBroker) Publish(data []byte) error {
+	return b.client.GetInstance().Conn.Publish(b.subject, data)
+}
+
+func (b *natsBroker) Subscribe(handler func(data []byte)) (func() error, error) {
+	conn := b.client.GetInstance().Conn
+	sub, err := conn.Subscribe(b.subject, func(msg *nats.Msg) {
+		handler(msg.Data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the subscription is live on the server once the flush round trips
+	if err := conn.Flush(); err != nil {
+		sub.Unsubscribe()
+		return nil, err
+	}
+	return sub.Unsubscribe, nil
+}
@@ -0,0 +1,55 @@
+package ws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/micro"
+	"github.com/nats-io/nats-server/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNatsBroker_FanOut(t *testing.T) {
+	opts := test.DefaultTestOptions
+	opts.Port = -1
+	s := test.RunServer(&opts)
+	defer s.Shutdown()
+
+	newHub := func(name string) Hub {
+		client, err := micro.ConnectNatsClient(context.Background(), &micro.Config{
+			NatsUrl:            s.ClientURL(),
+			NatsServiceName:    name,
+			NatsServiceVersion: "1.0.0",
+			Timeout:            time.Second,
+			Logger:             logger.Nop(),
+		})
+		assert.NoError(t, err)
+		t.Cleanup(client.Disconnect)
+
+		hub, err := NewHub(HubConfig{Broker: NewNatsBroker(client, "ws.broadcast"), Logger: logger.Nop()})
+		assert.NoError(t, err)
+		t.Cleanup(func() { hub.Close() })
+		return hub
+	}
+
+	hubA := newHub("a")
+	hubB := newHub("b")
+	a := newTestConn(t, hubA, 4)
+	b := newTestConn(t, hubB, 4)
+	a.Join("lobby")
+	b.Join("lobby")
+
+	msg, _ := NewMessage("news", "hi")
+	assert.NoError(t, hubA.Broadcast("lobby", msg))
+
+	for _, c := range []*conn{a, b} {
+		select {
+		case data := <-c.send:
+			assert.JSONEq(t, `{"type":"news","data":"hi"}`, string(data))
+		case <-time.After(2 * time.Second):
+			t.Fatal("broadcast not delivered")
+		}
+	}
+}
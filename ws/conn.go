@@ -0,0 +1,196 @@
+package ws
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var (
+	ErrClosed       = errors.New("connection closed")
+	ErrSlowConsumer = errors.New("send buffer full")
+)
+
+type Conn interface {
+	Id() string
+	// Get returns a value set on the gin context before the upgrade, such
+	// as the principal set by the authentication middleware.
+	Get(key any) (any, bool)
+	Send(msg *Message) error
+	Join(room string)
+	Leave(room string)
+	Close() error
+}
+
+type conn struct {
+	id     string
+	ws     *websocket.Conn
+	keys   map[any]any
+	hub    Hub
+	config *Config
+
+	send      chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+	closeCode int
+	closeText string
+}
+
+func newConn(id string, ws *websocket.Conn, keys map[any]any, hub Hub, config *Config) *conn {
+	return &conn{
+		id:        id,
+		ws:        ws,
+		keys:      keys,
+		hub:       hub,
+		config:    config,
+		send:      make(chan []byte, config.SendBuffer),
+		done:      make(chan struct{}),
+		closeCode: websocket.CloseNormalClosure,
+	}
+}
+
+func (c *conn) Id() string {
+	return c.id
+}
+
+func (c *conn) Get(key any) (any, bool) {
+	v, ok := c.keys[key]
+	return v, ok
+}
+
+func (c *conn) Send(msg *Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return c.sendRaw(data)
+}
+
+// sendRaw never blocks: a client that can not keep up with its buffer is
+// disconnected, so one slow reader can not stall a broadcast.
+func (c *conn) sendRaw(data []byte) error {
+	select {
+	case <-c.done:
+		return ErrClosed
+	default:
+	}
+
+	select {
+	case c.send <- data:
+		return nil
+	case <-c.done:
+		return ErrClosed
+	default:
+		c.closeWith(websocket.ClosePolicyViolation, "slow consumer")
+		return ErrSlowConsumer
+	}
+}
+
+func (c *conn) Join(room string) {
+	c.hub.Join(c, room)
+}
+
+func (c *conn) Leave(room string) {
+	c.hub.Leave(c, room)
+}
+
+func (c *conn) Close() error {
+	c.closeWith(websocket.CloseNormalClosure, "")
+	return nil
+}
+
+func (c *conn) closeWith(code int, text string) {
+	c.closeOnce.Do(func() {
+		c.closeCode = code
+		c.closeText = text
+		close(c.done)
+		c.hub.Unregister(c)
+	})
+}
+
+func (c *conn) writePump() {
+	ticker := time.NewTicker(c.config.PongWait * 9 / 10)
+	defer func() {
+		ticker.Stop()
+		c.ws.Close()
+	}()
+
+	for {
+		select {
+		case data := <-c.send:
+			c.ws.SetWriteDeadline(time.Now().Add(c.config.WriteTimeout))
+			if err := c.ws.WriteMessage(websocket.TextMessage, data); err != nil {
+				c.closeWith(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ticker.C:
+			deadline := time.Now().Add(c.config.WriteTimeout)
+			if err := c.ws.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				c.closeWith(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-c.done:
+			// queued messages get one write timeout in total to go out
+			deadline := time.Now().Add(c.config.WriteTimeout)
+			c.ws.SetWriteDeadline(deadline)
+			c.flush()
+			c.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(c.closeCode, c.closeText), deadline)
+			return
+		}
+	}
+}
+
+func (c *conn) flush() {
+	for {
+		select {
+		case data := <-c.send:
+			if err := c.ws.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// readPump blocks until the client goes away or the connection is closed,
+// dispatching messages in the order they arrive.
+func (c *conn) readPump(dispatch func(c *conn, msg *Message)) {
+	defer c.closeWith(websocket.CloseNormalClosure, "")
+
+	c.ws.SetReadLimit(c.config.ReadLimit)
+	c.ws.SetReadDeadline(time.Now().Add(c.config.PongWait))
+	c.ws.SetPongHandler(func(string) error {
+		return c.ws.SetReadDeadline(time.Now().Add(c.config.PongWait))
+	})
+
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg Message
+		if err := json.Unmarshal(data, &msg); err != nil || msg.Type == "" {
+			c.sendError(nil, "invalid message", nil)
+			continue
+		}
+		dispatch(c, &msg)
+	}
+}
+
+func (c *conn) sendError(req *Message, message string, data *ErrorData) {
+	if data == nil {
+		data = &ErrorData{Message: message}
+	}
+	msg, err := NewMessage(TypeError, data)
+	if err != nil {
+		return
+	}
+	if req != nil {
+		msg.Id = req.Id
+	}
+	c.Send(msg)
+}
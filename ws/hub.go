@@ -0,0 +1,185 @@
+package ws
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/afteracademy/goserve/v2/logger"
+)
+
+// Hub tracks connections and the rooms they joined. Broadcast to room ""
+// reaches every connection.
+type Hub interface {
+	Register(conn Conn)
+	Unregister(conn Conn)
+	Join(conn Conn, room string)
+	Leave(conn Conn, room string)
+	Broadcast(room string, msg *Message) error
+	Count(room string) int
+	Close() error
+}
+
+type HubConfig struct {
+	// Broker fans broadcasts out to the hubs of all instances; without it
+	// broadcasts only reach connections of this process.
+	Broker Broker
+	Logger logger.Logger
+}
+
+type hub struct {
+	mu          sync.RWMutex
+	conns       map[Conn]map[string]struct{}
+	rooms       map[string]map[Conn]struct{}
+	broker      Broker
+	unsubscribe func() error
+	logger      logger.Logger
+}
+
+// broadcast is the payload exchanged through the broker
+type broadcast struct {
+	Room    string   `json:"room"`
+	Message *Message `json:"message"`
+}
+
+func NewHub(config HubConfig) (Hub, error) {
+	h := &hub{
+		conns:  make(map[Conn]map[string]struct{}),
+		rooms:  make(map[string]map[Conn]struct{}),
+		broker: config.Broker,
+		logger: logger.Or(config.Logger),
+	}
+	if h.broker != nil {
+		unsubscribe, err := h.broker.Subscribe(h.receive)
+		if err != nil {
+			return nil, err
+		}
+		h.unsubscribe = unsubscribe
+	}
+	return h, nil
+}
+
+func (h *hub) Register(conn Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.conns[conn]; !ok {
+		h.conns[conn] = make(map[string]struct{})
+	}
+}
+
+func (h *hub) Unregister(conn Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for room := range h.conns[conn] {
+		h.leave(conn, room)
+	}
+	delete(h.conns, conn)
+}
+
+func (h *hub) Join(conn Conn, room string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	rooms, ok := h.conns[conn]
+	if !ok {
+		return
+	}
+	rooms[room] = struct{}{}
+	if h.rooms[room] == nil {
+		h.rooms[room] = make(map[Conn]struct{})
+	}
+	h.rooms[room][conn] = struct{}{}
+}
+
+func (h *hub) Leave(conn Conn, room string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.leave(conn, room)
+}
+
+func (h *hub) leave(conn Conn, room string) {
+	delete(h.conns[conn], room)
+	delete(h.rooms[room], conn)
+	if len(h.rooms[room]) == 0 {
+		delete(h.rooms, room)
+	}
+}
+
+func (h *hub) Count(room string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if room == "" {
+		return len(h.conns)
+	}
+	return len(h.rooms[room])
+}
+
+func (h *hub) Broadcast(room string, msg *Message) error {
+	if h.broker == nil {
+		h.deliver(room, msg)
+		return nil
+	}
+	data, err := json.Marshal(&broadcast{Room: room, Message: msg})
+	if err != nil {
+		return err
+	}
+	return h.broker.Publish(data)
+}
+
+// receive delivers broker broadcasts, including the ones this hub published
+func (h *hub) receive(data []byte) {
+	var b broadcast
+	if err := json.Unmarshal(data, &b); err != nil || b.Message == nil {
+		h.logger.Warn("invalid websocket broadcast", "error", err)
+		return
+	}
+	h.deliver(b.Room, b.Message)
+}
+
+func (h *hub) deliver(room string, msg *Message) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		h.logger.Error("websocket broadcast encoding failed", "error", err)
+		return
+	}
+
+	// sending may unregister slow connections, so it happens outside the lock
+	h.mu.RLock()
+	var targets []Conn
+	if room == "" {
+		targets = make([]Conn, 0, len(h.conns))
+		for c := range h.conns {
+			targets = append(targets, c)
+		}
+	} else {
+		targets = make([]Conn, 0, len(h.rooms[room]))
+		for c := range h.rooms[room] {
+			targets = append(targets, c)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, c := range targets {
+		if raw, ok := c.(*conn); ok {
+			raw.sendRaw(data)
+		} else {
+			c.Send(msg)
+		}
+	}
+}
+
+// Close disconnects all connections and stops receiving broadcasts.
+func (h *hub) Close() error {
+	h.mu.RLock()
+	conns := make([]Conn, 0, len(h.conns))
+	for c := range h.conns {
+		conns = append(conns, c)
+	}
+	h.mu.RUnlock()
+
+	for _, c := range conns {
+		c.Close()
+	}
+	if h.unsubscribe != nil {
+		return h.unsubscribe()
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestConn(t *testing.T, hub Hub, buffer int) *conn {
+	t.Helper()
+	c := newConn("id", nil, nil, hub, &Config{SendBuffer: buffer})
+	hub.Register(c)
+	return c
+}
+
+func TestHub_Rooms(t *testing.T) {
+	hub, err := NewHub(HubConfig{Logger: logger.Nop()})
+	assert.NoError(t, err)
+
+	a := newTestConn(t, hub, 4)
+	b := newTestConn(t, hub, 4)
+	a.Join("red")
+	b.Join("blue")
+	assert.Equal(t, 2, hub.Count(""))
+	assert.Equal(t, 1, hub.Count("red"))
+
+	msg, _ := NewMessage("news", "hi")
+	assert.NoError(t, hub.Broadcast("red", msg))
+	assert.Len(t, a.send, 1)
+	assert.Len(t, b.send, 0)
+
+	assert.NoError(t, hub.Broadcast("", msg))
+	assert.Len(t, a.send, 2)
+	assert.Len(t, b.send, 1)
+
+	a.Leave("red")
+	assert.Equal(t, 0, hub.Count("red"))
+
+	assert.NoError(t, hub.Close())
+	assert.Equal(t, 0, hub.Count(""))
+	assert.ErrorIs(t, a.Send(msg), ErrClosed)
+}
+
+func TestHub_JoinUnregistered(t *testing.T) {
+	hub, _ := NewHub(HubConfig{})
+	c := newConn("id", nil, nil, hub, &Config{SendBuffer: 1})
+
+	c.Join("red")
+	assert.Equal(t, 0, hub.Count("red"))
+}
+
+func TestHub_SlowConsumerDisconnected(t *testing.T) {
+	hub, _ := NewHub(HubConfig{})
+	c := newTestConn(t, hub, 1)
+	c.Join("red")
+
+	msg, _ := NewMessage("news", "hi")
+	assert.NoError(t, c.Send(msg))
+	assert.ErrorIs(t, c.Send(msg), ErrSlowConsumer)
+	assert.Equal(t, 0, hub.Count(""))
+	assert.Equal(t, 0, hub.Count("red"))
+	assert.ErrorIs(t, c.Send(msg), ErrClosed)
+}
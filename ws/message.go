@@ -0,0 +1,60 @@
+package ws
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/afteracademy/goserve/v2/network"
+)
+
+const TypeError = "error"
+
+var ErrUnknownType = errors.New("unknown message type")
+
+// Message is the JSON envelope of every frame in both directions. Id is
+// set by clients that want to correlate an error reply with their request.
+type Message struct {
+	Type string          `json:"type"`
+	Id   string          `json:"id,omitempty"`
+	Room string          `json:"room,omitempty"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+func NewMessage(msgType string, data any) (*Message, error) {
+	msg := &Message{Type: msgType}
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		msg.Data = raw
+	}
+	return msg, nil
+}
+
+type ErrorData struct {
+	Message string               `json:"message"`
+	Errors  []network.FieldError `json:"errors,omitempty"`
+}
+
+// Handler processes one message type. Returning an error replies to the
+// sender with a TypeError message; the connection stays open.
+type Handler func(conn Conn, msg *Message) error
+
+// On decodes the message data into T and validates it with
+// network.ValidateDto before calling fn.
+func On[T any](fn func(conn Conn, payload *T) error) Handler {
+	return func(conn Conn, msg *Message) error {
+		var payload T
+		if len(msg.Data) > 0 {
+			if err := json.Unmarshal(msg.Data, &payload); err != nil {
+				return network.NewBadRequestError("invalid message data", err)
+			}
+		}
+		value, err := network.ValidateDto(&payload)
+		if err != nil {
+			return err
+		}
+		return fn(conn, value)
+	}
+}
@@ -0,0 +1,148 @@
+package ws
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+type Config struct {
+	// Hub is shared by servers that broadcast to each other's clients, a
+	// local hub is created when nil.
+	Hub Hub
+	// ReadLimit is the max frame size accepted from clients, 64KiB by default.
+	ReadLimit int64
+	// SendBuffer is the number of outgoing messages queued per connection,
+	// 64 by default. Clients falling further behind are disconnected.
+	SendBuffer int
+	// PongWait is how long a client may stay silent, 60s by default. Pings
+	// are sent at 9/10 of it.
+	PongWait     time.Duration
+	WriteTimeout time.Duration
+	// CheckOrigin defaults to allowing same origin requests only.
+	CheckOrigin func(r *http.Request) bool
+	// OnConnect runs after the upgrade; an error closes the connection.
+	OnConnect    func(conn Conn) error
+	OnDisconnect func(conn Conn)
+	Logger       logger.Logger
+}
+
+// Server upgrades requests to WebSocket connections and dispatches their
+// messages by type. Mount Handler in a network.Controller after the
+// Authentication and Authorization middlewares to protect it.
+type Server interface {
+	Hub() Hub
+	Handle(msgType string, handler Handler)
+	Handler() gin.HandlerFunc
+}
+
+type server struct {
+	config   Config
+	upgrader websocket.Upgrader
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	logger   logger.Logger
+}
+
+func NewServer(config Config) Server {
+	if config.Hub == nil {
+		// a hub without broker can not fail
+		config.Hub, _ = NewHub(HubConfig{Logger: config.Logger})
+	}
+	if config.ReadLimit <= 0 {
+		config.ReadLimit = 64 << 10
+	}
+	if config.SendBuffer <= 0 {
+		config.SendBuffer = 64
+	}
+	if config.PongWait <= 0 {
+		config.PongWait = 60 * time.Second
+	}
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = 10 * time.Second
+	}
+	return &server{
+		config:   config,
+		upgrader: websocket.Upgrader{CheckOrigin: config.CheckOrigin},
+		handlers: make(map[string]Handler),
+		logger:   logger.Or(config.Logger),
+	}
+}
+
+func (s *server) Hub() Hub {
+	return s.config.Hub
+}
+
+func (s *server) Handle(msgType string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[msgType] = handler
+}
+
+func (s *server) Handler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// the upgrader replies with an error status itself on failure
+		wsConn, err := s.upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+		if err != nil {
+			ctx.Abort()
+			return
+		}
+
+		keys := make(map[any]any, len(ctx.Keys))
+		for k, v := range ctx.Keys {
+			keys[k] = v
+		}
+
+		c := newConn(uuid.NewString(), wsConn, keys, s.config.Hub, &s.config)
+		s.config.Hub.Register(c)
+		go c.writePump()
+
+		if s.config.OnConnect != nil {
+			if err := s.config.OnConnect(c); err != nil {
+				c.sendError(nil, err.Error(), nil)
+				c.closeWith(websocket.ClosePolicyViolation, "connection rejected")
+				return
+			}
+		}
+
+		c.readPump(s.dispatch)
+
+		if s.config.OnDisconnect != nil {
+			s.config.OnDisconnect(c)
+		}
+	}
+}
+
+func (s *server) dispatch(c *conn, msg *Message) {
+	s.mu.RLock()
+	handler, ok := s.handlers[msg.Type]
+	s.mu.RUnlock()
+	if !ok {
+		c.sendError(msg, ErrUnknownType.Error(), nil)
+		return
+	}
+
+	err := handler(c, msg)
+	if err == nil {
+		return
+	}
+
+	var validationError *network.ValidationError
+	var apiError network.ApiError
+	switch {
+	case errors.As(err, &validationError):
+		c.sendError(msg, "", &ErrorData{Message: err.Error(), Errors: validationError.Errors})
+	case errors.As(err, &apiError) && apiError.GetCode() != http.StatusInternalServerError:
+		c.sendError(msg, apiError.GetMessage(), nil)
+	default:
+		s.logger.Error("websocket handler failed", "type", msg.Type, "error", err)
+		c.sendError(msg, "something went wrong", nil)
+	}
+}
@@ -0,0 +1,185 @@
+package ws
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/afteracademy/goserve/v2/logger"
+	"github.com/afteracademy/goserve/v2/network"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+type chatPayload struct {
+	Text string `json:"text" validate:"required,min=2"`
+}
+
+type mockAuth struct{}
+
+func (mockAuth) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader(network.AuthorizationHeader) != "Bearer token" {
+			network.SendUnauthorizedError(ctx, "unauthenticated", nil)
+			return
+		}
+		ctx.Set("user", "alice")
+		ctx.Next()
+	}
+}
+
+type chatController struct {
+	network.Controller
+	server Server
+}
+
+func (c *chatController) MountRoutes(group *gin.RouterGroup) {
+	group.GET("/", c.Authentication(), c.server.Handler())
+}
+
+func newTestServer(t *testing.T, config Config) (Server, string) {
+	t.Helper()
+	config.Logger = logger.Nop()
+	server := NewServer(config)
+	server.Handle("chat", On(func(conn Conn, payload *chatPayload) error {
+		user, _ := conn.Get("user")
+		msg, err := NewMessage("chat", map[string]string{"from": user.(string), "text": payload.Text})
+		if err != nil {
+			return err
+		}
+		return conn.Send(msg)
+	}))
+	server.Handle("fail", func(conn Conn, msg *Message) error {
+		return errors.New("internal detail")
+	})
+
+	router := network.NewRouterWithConfig(network.RouterConfig{Mode: gin.TestMode, Logger: logger.Nop()})
+	router.LoadControllers([]network.Controller{
+		&chatController{network.NewController("/ws", mockAuth{}, nil), server},
+	})
+	s := httptest.NewServer(router.GetEngine())
+	t.Cleanup(s.Close)
+	return server, "ws" + strings.TrimPrefix(s.URL, "http") + "/ws/"
+}
+
+func dial(t *testing.T, url string) *websocket.Conn {
+	t.Helper()
+	header := http.Header{network.AuthorizationHeader: []string{"Bearer token"}}
+	c, _, err := websocket.DefaultDialer.Dial(url, header)
+	assert.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func read(t *testing.T, c *websocket.Conn) *Message {
+	t.Helper()
+	c.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg Message
+	assert.NoError(t, c.ReadJSON(&msg))
+	return &msg
+}
+
+func TestServer_TypedMessage(t *testing.T) {
+	_, url := newTestServer(t, Config{})
+	c := dial(t, url)
+
+	assert.NoError(t, c.WriteJSON(map[string]any{"type": "chat", "data": map[string]string{"text": "hello"}}))
+	msg := read(t, c)
+	assert.Equal(t, "chat", msg.Type)
+	assert.JSONEq(t, `{"from":"alice","text":"hello"}`, string(msg.Data))
+}
+
+func TestServer_ValidationError(t *testing.T) {
+	_, url := newTestServer(t, Config{})
+	c := dial(t, url)
+
+	assert.NoError(t, c.WriteJSON(map[string]any{"type": "chat", "id": "7", "data": map[string]string{"text": "x"}}))
+	msg := read(t, c)
+	assert.Equal(t, TypeError, msg.Type)
+	assert.Equal(t, "7", msg.Id)
+	assert.Contains(t, string(msg.Data), `"field":"text"`)
+	assert.Contains(t, string(msg.Data), `"tag":"min"`)
+}
+
+func TestServer_ErrorReplies(t *testing.T) {
+	_, url := newTestServer(t, Config{})
+	c := dial(t, url)
+
+	assert.NoError(t, c.WriteJSON(map[string]any{"type": "unknown"}))
+	assert.JSONEq(t, `{"message":"unknown message type"}`, string(read(t, c).Data))
+
+	assert.NoError(t, c.WriteJSON(map[string]any{"type": "fail"}))
+	assert.JSONEq(t, `{"message":"something went wrong"}`, string(read(t, c).Data))
+
+	assert.NoError(t, c.WriteMessage(websocket.TextMessage, []byte("not json")))
+	assert.JSONEq(t, `{"message":"invalid message"}`, string(read(t, c).Data))
+}
+
+func TestServer_Unauthenticated(t *testing.T) {
+	_, url := newTestServer(t, Config{})
+
+	_, res, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func TestServer_OnConnectRejects(t *testing.T) {
+	_, url := newTestServer(t, Config{
+		OnConnect: func(conn Conn) error { return errors.New("room full") },
+	})
+	c := dial(t, url)
+
+	assert.JSONEq(t, `{"message":"room full"}`, string(read(t, c).Data))
+	_, _, err := c.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation))
+}
+
+func TestServer_Broadcast(t *testing.T) {
+	disconnected := make(chan struct{}, 2)
+	server, url := newTestServer(t, Config{
+		OnConnect: func(conn Conn) error {
+			conn.Join("lobby")
+			return nil
+		},
+		OnDisconnect: func(conn Conn) { disconnected <- struct{}{} },
+	})
+	a := dial(t, url)
+	b := dial(t, url)
+	assert.Eventually(t, func() bool { return server.Hub().Count("lobby") == 2 }, time.Second, 10*time.Millisecond)
+
+	msg, err := NewMessage("news", "hi")
+	assert.NoError(t, err)
+	assert.NoError(t, server.Hub().Broadcast("lobby", msg))
+	assert.Equal(t, "news", read(t, a).Type)
+	assert.Equal(t, "news", read(t, b).Type)
+
+	a.Close()
+	<-disconnected
+	assert.Equal(t, 1, server.Hub().Count("lobby"))
+}
+
+func TestServer_Ping(t *testing.T) {
+	_, url := newTestServer(t, Config{PongWait: 50 * time.Millisecond})
+	c := dial(t, url)
+
+	pinged := make(chan struct{}, 1)
+	c.SetPingHandler(func(data string) error {
+		select {
+		case pinged <- struct{}{}:
+		default:
+		}
+		return c.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	// control frames are handled while reading
+	go c.ReadMessage()
+
+	select {
+	case <-pinged:
+	case <-time.After(time.Second):
+		t.Fatal("no ping received")
+	}
+}